@@ -0,0 +1,302 @@
+// Command apigen generates the RunPod API request and response types used by
+// the provider client from the OpenAPI specification in the repository root.
+//
+// It is invoked through go generate from internal/provider/client.go:
+//
+//	go run ../apigen -spec ../../openapi.json -overlay ../../openapi.overlay.json -out client_types_gen.go -types Pod,...
+//
+// The overlay adds the fields the published specification is missing; see
+// package apispec.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"terraform-provider-runpod/internal/apispec"
+)
+
+// schema is the subset of an OpenAPI schema object understood by apigen.
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Required   []string           `json:"required"`
+	Properties map[string]*schema `json:"properties"`
+	Items      *schema            `json:"items"`
+}
+
+type spec struct {
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// initialisms maps lower-case field prefixes to the spelling used throughout
+// the provider, e.g. gpuTypeIds becomes GPUTypeIds.
+var initialisms = []struct{ prefix, name string }{
+	{"vcpu", "VCPU"},
+	{"gpu", "GPU"},
+	{"cpu", "CPU"},
+}
+
+func main() {
+	specPath := flag.String("spec", "openapi.json", "path to the OpenAPI specification")
+	overlayPath := flag.String("overlay", "", "path to a JSON Merge Patch applied to the specification")
+	outPath := flag.String("out", "client_types_gen.go", "path of the generated Go file")
+	pkg := flag.String("package", "provider", "package name of the generated file")
+	types := flag.String("types", "", "comma separated list of schemas to generate")
+	pointers := flag.String("pointers", "", "comma separated list of response schemas whose scalar fields are pointers")
+	flag.Parse()
+
+	raw, err := apispec.Load(*specPath, *overlayPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var s spec
+	if err := json.Unmarshal(raw, &s); err != nil {
+		log.Fatalf("error decoding spec: %s", err)
+	}
+
+	g := &generator{
+		schemas:  s.Components.Schemas,
+		pointers: splitList(*pointers),
+		emitted:  map[string]bool{},
+	}
+
+	for name := range splitList(*types) {
+		g.queue(name)
+	}
+
+	src, err := g.render(*pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		log.Fatalf("error writing output: %s", err)
+	}
+}
+
+func splitList(v string) map[string]bool {
+	out := map[string]bool{}
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out[item] = true
+		}
+	}
+	return out
+}
+
+// goType is a struct that will be emitted along with the schema it came from.
+type goType struct {
+	name   string
+	schema *schema
+	input  bool
+	ptrs   bool
+	source string
+}
+
+type generator struct {
+	schemas  map[string]*schema
+	pointers map[string]bool
+	emitted  map[string]bool
+	pending  []goType
+	roots    []string
+}
+
+// queue schedules a named component schema, and transitively every schema it
+// references, for generation.
+func (g *generator) queue(name string) {
+	if g.emitted[name] {
+		return
+	}
+	s, ok := g.schemas[name]
+	if !ok {
+		log.Fatalf("schema %q not found in spec", name)
+	}
+	g.emitted[name] = true
+	g.roots = append(g.roots, name)
+	g.pending = append(g.pending, goType{
+		name:   name,
+		schema: s,
+		input:  strings.HasSuffix(name, "Input"),
+		ptrs:   g.pointers[name],
+		source: name,
+	})
+}
+
+func (g *generator) render(pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by apigen from openapi.json; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	var bodies []string
+	var names []string
+	for len(g.pending) > 0 {
+		t := g.pending[0]
+		g.pending = g.pending[1:]
+		names = append(names, t.name)
+		bodies = append(bodies, g.renderStruct(t))
+	}
+
+	order := make([]int, len(names))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return names[order[a]] < names[order[b]] })
+	for _, i := range order {
+		buf.WriteString(bodies[i])
+	}
+
+	sort.Strings(g.roots)
+	buf.WriteString("// apiSchemaTypes maps each generated component schema to its Go type.\n")
+	buf.WriteString("var apiSchemaTypes = map[string]interface{}{\n")
+	for _, name := range g.roots {
+		fmt.Fprintf(&buf, "\t%q: %s{},\n", name, name)
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated source: %w\n%s", err, buf.String())
+	}
+	return src, nil
+}
+
+func (g *generator) renderStruct(t goType) string {
+	var buf bytes.Buffer
+
+	if t.source == t.name {
+		fmt.Fprintf(&buf, "// %s is generated from the %s schema.\n", t.name, t.source)
+	} else {
+		fmt.Fprintf(&buf, "// %s is generated from the inline %s schema.\n", t.name, t.source)
+	}
+	fmt.Fprintf(&buf, "type %s struct {\n", t.name)
+
+	required := map[string]bool{}
+	for _, r := range t.schema.Required {
+		required[r] = true
+	}
+
+	props := make([]string, 0, len(t.schema.Properties))
+	for prop := range t.schema.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	for _, prop := range props {
+		p := t.schema.Properties[prop]
+		field := fieldName(prop)
+		typ := g.fieldType(t, field, prop, p)
+
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+			if t.input || t.ptrs {
+				typ = pointerScalar(typ)
+			}
+		}
+
+		fmt.Fprintf(&buf, "\t%s %s `json:%q`\n", field, typ, tag)
+	}
+
+	buf.WriteString("}\n\n")
+	return buf.String()
+}
+
+// fieldType resolves the Go type of a property, queueing nested types.
+func (g *generator) fieldType(parent goType, field, prop string, p *schema) string {
+	if p.Ref != "" {
+		name := refName(p.Ref)
+		ref := g.schemas[name]
+		if ref != nil && ref.Type != "object" && ref.Type != "array" {
+			return scalarType(ref)
+		}
+		g.queue(name)
+		return "*" + name
+	}
+
+	switch p.Type {
+	case "array":
+		if p.Items == nil {
+			return "[]interface{}"
+		}
+		if p.Items.Ref != "" {
+			name := refName(p.Items.Ref)
+			ref := g.schemas[name]
+			if ref != nil && ref.Type != "object" {
+				return "[]" + scalarType(ref)
+			}
+			g.queue(name)
+			return "[]" + name
+		}
+		return "[]" + scalarType(p.Items)
+	case "object":
+		if len(p.Properties) > 0 {
+			name := parent.name + field
+			g.pending = append(g.pending, goType{
+				name:   name,
+				schema: p,
+				input:  parent.input,
+				ptrs:   parent.ptrs,
+				source: parent.source + "." + prop,
+			})
+			return "*" + name
+		}
+		if p.Items != nil {
+			return "map[string]" + scalarType(p.Items)
+		}
+		return "map[string]interface{}"
+	}
+
+	return scalarType(p)
+}
+
+func scalarType(s *schema) string {
+	switch s.Type {
+	case "string":
+		return "string"
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	return "interface{}"
+}
+
+// pointerScalar turns optional scalar fields into pointers so that the zero
+// value can be told apart from an omitted one.
+func pointerScalar(typ string) string {
+	switch typ {
+	case "int", "float64", "bool":
+		return "*" + typ
+	}
+	return typ
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func fieldName(prop string) string {
+	if prop == "id" {
+		return "ID"
+	}
+	for _, i := range initialisms {
+		if strings.HasPrefix(prop, i.prefix) {
+			return i.name + prop[len(i.prefix):]
+		}
+	}
+	return strings.ToUpper(prop[:1]) + prop[1:]
+}
@@ -0,0 +1,65 @@
+// Package apispec loads the RunPod OpenAPI specification used to generate and
+// check the provider's API client.
+//
+// openapi.json in the repository root is vendored unchanged from RunPod.
+// Fields the API returns but the published specification does not yet
+// describe are added by openapi.overlay.json, a JSON Merge Patch (RFC 7386)
+// applied on top of it, so that every provider-side change to the
+// specification is kept in one reviewable file.
+package apispec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Load reads the specification at specPath and, unless overlayPath is empty,
+// applies the overlay at overlayPath to it. It returns the resulting JSON.
+func Load(specPath, overlayPath string) ([]byte, error) {
+	raw, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading spec: %w", err)
+	}
+	if overlayPath == "" {
+		return raw, nil
+	}
+
+	overlayRaw, err := os.ReadFile(overlayPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading overlay: %w", err)
+	}
+
+	var spec, overlay interface{}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return nil, fmt.Errorf("error decoding spec: %w", err)
+	}
+	if err := json.Unmarshal(overlayRaw, &overlay); err != nil {
+		return nil, fmt.Errorf("error decoding overlay: %w", err)
+	}
+
+	return json.Marshal(mergePatch(spec, overlay))
+}
+
+// mergePatch applies a JSON Merge Patch to target: objects are merged key by
+// key, null removes a key, and any other value replaces the target.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for k, v := range patchObject {
+		if v == nil {
+			delete(targetObject, k)
+			continue
+		}
+		targetObject[k] = mergePatch(targetObject[k], v)
+	}
+	return targetObject
+}
@@ -0,0 +1,42 @@
+package apispec
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{"add property", `{"a":{"b":1}}`, `{"a":{"c":2}}`, `{"a":{"b":1,"c":2}}`},
+		{"replace value", `{"a":{"b":1}}`, `{"a":{"b":"x"}}`, `{"a":{"b":"x"}}`},
+		{"remove property", `{"a":{"b":1,"c":2}}`, `{"a":{"c":null}}`, `{"a":{"b":1}}`},
+		{"replace array", `{"a":[1,2]}`, `{"a":[3]}`, `{"a":[3]}`},
+		{"object over scalar", `{"a":1}`, `{"a":{"b":1}}`, `{"a":{"b":1}}`},
+		{"empty patch", `{"a":1}`, `{}`, `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var target, patch, want interface{}
+			for _, v := range []struct {
+				raw string
+				out *interface{}
+			}{{tt.target, &target}, {tt.patch, &patch}, {tt.want, &want}} {
+				if err := json.Unmarshal([]byte(v.raw), v.out); err != nil {
+					t.Fatalf("error decoding %s: %s", v.raw, err)
+				}
+			}
+
+			got, _ := json.Marshal(mergePatch(target, patch))
+			wantJSON, _ := json.Marshal(want)
+			if string(got) != string(wantJSON) {
+				t.Errorf("mergePatch(%s, %s) = %s, want %s", tt.target, tt.patch, got, wantJSON)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &AccountDataSource{}
+
+func NewAccountDataSource() datasource.DataSource {
+	return &AccountDataSource{}
+}
+
+type AccountDataSource struct {
+	client *Client
+}
+
+type AccountDataSourceModel struct {
+	ID         types.String  `tfsdk:"id"`
+	Email      types.String  `tfsdk:"email"`
+	Balance    types.Float64 `tfsdk:"balance"`
+	SpendPerHr types.Float64 `tfsdk:"spend_per_hr"`
+	SpendLimit types.Float64 `tfsdk:"spend_limit"`
+}
+
+func (d *AccountDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account"
+}
+
+func (d *AccountDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to read the RunPod account that owns the configured API key.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the user.",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email address of the user.",
+				Computed:            true,
+			},
+			"balance": schema.Float64Attribute{
+				MarkdownDescription: "The remaining credit balance of the account in USD.",
+				Computed:            true,
+			},
+			"spend_per_hr": schema.Float64Attribute{
+				MarkdownDescription: "The current rate at which the account spends credit, in USD per hour.",
+				Computed:            true,
+			},
+			"spend_limit": schema.Float64Attribute{
+				MarkdownDescription: "The spend limit of the account in USD per hour, or null when none is set.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AccountDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AccountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AccountDataSourceModel
+
+	tflog.Debug(ctx, "Reading Account data source")
+
+	account, err := d.client.GetAccount(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read account, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(account.ID)
+	data.Email = types.StringValue(account.Email)
+	data.Balance = types.Float64Value(account.ClientBalance)
+	data.SpendPerHr = types.Float64Value(account.CurrentSpendPerHr)
+	data.SpendLimit = types.Float64PointerValue(account.SpendLimit)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
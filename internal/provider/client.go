@@ -1,22 +1,29 @@
 package provider
 
+//go:generate go run ../apigen -spec ../../openapi.json -overlay ../../openapi.overlay.json -out client_types_gen.go -pointers Pod,Endpoint -types Pod,PodCreateInput,PodUpdateInput,PodUpdateInPlaceInput,Endpoint,EndpointCreateInput,EndpointUpdateInput,NetworkVolume,NetworkVolumeCreateInput,NetworkVolumeUpdateInput,Template,TemplateCreateInput,TemplateUpdateInput,BillingRecord
+
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
 const (
-	defaultBaseURL = "https://rest.runpod.io/v1"
+	defaultBaseURL    = "https://rest.runpod.io/v1"
+	defaultGraphQLURL = "https://api.runpod.io/graphql"
 )
 
 // Client is the RunPod API client
 type Client struct {
 	BaseURL    string
+	GraphQLURL string
 	APIKey     string
 	HTTPClient *http.Client
 }
@@ -24,14 +31,32 @@ type Client struct {
 // NewClient creates a new RunPod API client
 func NewClient(apiKey string) *Client {
 	return &Client{
-		BaseURL: defaultBaseURL,
-		APIKey:  apiKey,
+		BaseURL:    defaultBaseURL,
+		GraphQLURL: defaultGraphQLURL,
+		APIKey:     apiKey,
 		HTTPClient: &http.Client{
 			Timeout: time.Minute * 5,
 		},
 	}
 }
 
+// APIError is returned when the API responds with an error status.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// isNotFound reports whether err is an API response saying that the
+// requested object does not exist.
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
 // doRequest performs an HTTP request with authentication
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
 	var reqBody io.Reader
@@ -59,119 +84,228 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	return resp, nil
 }
 
-// Pod represents a RunPod Pod
-type Pod struct {
-	ID                      string                 `json:"id,omitempty"`
-	Name                    string                 `json:"name,omitempty"`
-	ImageName               string                 `json:"image,omitempty"`
-	ComputeType             string                 `json:"computeType,omitempty"`
-	CloudType               string                 `json:"cloudType,omitempty"`
-	GPUCount                int                    `json:"gpuCount,omitempty"`
-	VCPUCount               int                    `json:"vcpuCount,omitempty"`
-	MemoryInGb              float64                `json:"memoryInGb,omitempty"`
-	GPUTypeIds              []string               `json:"gpuTypeIds,omitempty"`
-	CPUFlavorIds            []string               `json:"cpuFlavorIds,omitempty"`
-	DataCenterIds           []string               `json:"dataCenterIds,omitempty"`
-	ContainerDiskInGb       int                    `json:"containerDiskInGb,omitempty"`
-	VolumeInGb              int                    `json:"volumeInGb,omitempty"`
-	VolumeMountPath         string                 `json:"volumeMountPath,omitempty"`
-	Ports                   []string               `json:"ports,omitempty"`
-	Env                     map[string]string      `json:"env,omitempty"`
-	DockerEntrypoint        []string               `json:"dockerEntrypoint,omitempty"`
-	DockerStartCmd          []string               `json:"dockerStartCmd,omitempty"`
-	TemplateId              string                 `json:"templateId,omitempty"`
-	NetworkVolumeId         string                 `json:"networkVolumeId,omitempty"`
-	Interruptible           bool                   `json:"interruptible,omitempty"`
-	Locked                  bool                   `json:"locked,omitempty"`
-	MinVCPUPerGPU           int                    `json:"minVCPUPerGPU,omitempty"`
-	MinRAMPerGPU            int                    `json:"minRAMPerGPU,omitempty"`
-	MinDownloadMbps         float64                `json:"minDownloadMbps,omitempty"`
-	MinUploadMbps           float64                `json:"minUploadMbps,omitempty"`
-	MinDiskBandwidthMBps    float64                `json:"minDiskBandwidthMBps,omitempty"`
-	SupportPublicIp         *bool                  `json:"supportPublicIp,omitempty"`
-	GlobalNetworking        bool                   `json:"globalNetworking,omitempty"`
-	AllowedCudaVersions     []string               `json:"allowedCudaVersions,omitempty"`
-	CountryCodes            []string               `json:"countryCodes,omitempty"`
-	GPUTypePriority         string                 `json:"gpuTypePriority,omitempty"`
-	CPUFlavorPriority       string                 `json:"cpuFlavorPriority,omitempty"`
-	DataCenterPriority      string                 `json:"dataCenterPriority,omitempty"`
-	ContainerRegistryAuthId string                 `json:"containerRegistryAuthId,omitempty"`
-	DesiredStatus           string                 `json:"desiredStatus,omitempty"`
-	PublicIp                string                 `json:"publicIp,omitempty"`
-	PortMappings            map[string]int         `json:"portMappings,omitempty"`
-	MachineId               string                 `json:"machineId,omitempty"`
-	CostPerHr               float64                `json:"costPerHr,omitempty"`
-	AdjustedCostPerHr       float64                `json:"adjustedCostPerHr,omitempty"`
-	LastStartedAt           string                 `json:"lastStartedAt,omitempty"`
-	LastStatusChange        string                 `json:"lastStatusChange,omitempty"`
-	Machine                 map[string]interface{} `json:"machine,omitempty"`
-	GPU                     map[string]interface{} `json:"gpu,omitempty"`
-	NetworkVolume           map[string]interface{} `json:"networkVolume,omitempty"`
-}
-
-// PodCreateInput represents the input for creating a Pod
-type PodCreateInput struct {
-	Name                    string            `json:"name,omitempty"`
-	ImageName               string            `json:"imageName,omitempty"`
-	ComputeType             string            `json:"computeType,omitempty"`
-	CloudType               string            `json:"cloudType,omitempty"`
-	GPUCount                *int              `json:"gpuCount,omitempty"`
-	VCPUCount               *int              `json:"vcpuCount,omitempty"`
-	GPUTypeIds              []string          `json:"gpuTypeIds,omitempty"`
-	CPUFlavorIds            []string          `json:"cpuFlavorIds,omitempty"`
-	DataCenterIds           []string          `json:"dataCenterIds,omitempty"`
-	ContainerDiskInGb       *int              `json:"containerDiskInGb,omitempty"`
-	VolumeInGb              *int              `json:"volumeInGb,omitempty"`
-	VolumeMountPath         string            `json:"volumeMountPath,omitempty"`
-	Ports                   []string          `json:"ports,omitempty"`
-	Env                     map[string]string `json:"env,omitempty"`
-	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
-	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
-	TemplateId              string            `json:"templateId,omitempty"`
-	NetworkVolumeId         string            `json:"networkVolumeId,omitempty"`
-	Interruptible           *bool             `json:"interruptible,omitempty"`
-	Locked                  *bool             `json:"locked,omitempty"`
-	MinVCPUPerGPU           *int              `json:"minVCPUPerGPU,omitempty"`
-	MinRAMPerGPU            *int              `json:"minRAMPerGPU,omitempty"`
-	MinDownloadMbps         *float64          `json:"minDownloadMbps,omitempty"`
-	MinUploadMbps           *float64          `json:"minUploadMbps,omitempty"`
-	MinDiskBandwidthMBps    *float64          `json:"minDiskBandwidthMBps,omitempty"`
-	SupportPublicIp         *bool             `json:"supportPublicIp,omitempty"`
-	GlobalNetworking        *bool             `json:"globalNetworking,omitempty"`
-	AllowedCudaVersions     []string          `json:"allowedCudaVersions,omitempty"`
-	CountryCodes            []string          `json:"countryCodes,omitempty"`
-	GPUTypePriority         string            `json:"gpuTypePriority,omitempty"`
-	CPUFlavorPriority       string            `json:"cpuFlavorPriority,omitempty"`
-	DataCenterPriority      string            `json:"dataCenterPriority,omitempty"`
-	ContainerRegistryAuthId string            `json:"containerRegistryAuthId,omitempty"`
-}
-
-// PodUpdateInput represents the input for updating a Pod (triggers reset)
-type PodUpdateInput struct {
-	Name                    string            `json:"name,omitempty"`
-	ImageName               string            `json:"imageName,omitempty"`
-	ContainerDiskInGb       *int              `json:"containerDiskInGb,omitempty"`
-	VolumeInGb              *int              `json:"volumeInGb,omitempty"`
-	VolumeMountPath         string            `json:"volumeMountPath,omitempty"`
-	Ports                   []string          `json:"ports,omitempty"`
-	Env                     map[string]string `json:"env,omitempty"`
-	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
-	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
-	Locked                  *bool             `json:"locked,omitempty"`
-	GlobalNetworking        *bool             `json:"globalNetworking,omitempty"`
-	ContainerRegistryAuthId string            `json:"containerRegistryAuthId,omitempty"`
-}
-
-// PodUpdateInPlaceInput represents the input for updating a Pod in place (no reset)
-type PodUpdateInPlaceInput struct {
-	Name   string `json:"name,omitempty"`
-	Locked *bool  `json:"locked,omitempty"`
+// doGraphQL performs a request against the GraphQL API, which exposes
+// account information the REST API does not, and decodes its data into out
+func (c *Client) doGraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.GraphQLURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error performing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+
+	if len(result.Errors) > 0 {
+		messages := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("GraphQL request failed: %s", strings.Join(messages, "; "))
+	}
+
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateAPIKey makes a lightweight authenticated request to check that the
+// API key is accepted
+func (c *Client) ValidateAPIKey(ctx context.Context) error {
+	resp, err := c.doRequest(ctx, "GET", "/containerregistryauth", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// Account is the user that owns the API key. The REST API does not describe
+// users, so it is read from the GraphQL API.
+type Account struct {
+	ID                string   `json:"id"`
+	Email             string   `json:"email"`
+	ClientBalance     float64  `json:"clientBalance"`
+	CurrentSpendPerHr float64  `json:"currentSpendPerHr"`
+	SpendLimit        *float64 `json:"spendLimit"`
+}
+
+// GetAccount retrieves the user that owns the API key
+func (c *Client) GetAccount(ctx context.Context) (*Account, error) {
+	var data struct {
+		Myself Account `json:"myself"`
+	}
+
+	query := `query { myself { id email clientBalance currentSpendPerHr spendLimit } }`
+	if err := c.doGraphQL(ctx, query, nil, &data); err != nil {
+		return nil, err
+	}
+
+	return &data.Myself, nil
+}
+
+// GetPublicKeys retrieves the SSH public keys in the user settings of the
+// account, one per line. RunPod adds them to every Pod.
+func (c *Client) GetPublicKeys(ctx context.Context) (string, error) {
+	var data struct {
+		Myself struct {
+			PubKey string `json:"pubKey"`
+		} `json:"myself"`
+	}
+
+	query := `query { myself { pubKey } }`
+	if err := c.doGraphQL(ctx, query, nil, &data); err != nil {
+		return "", err
+	}
+
+	return data.Myself.PubKey, nil
+}
+
+// UpdatePublicKeys replaces the SSH public keys in the user settings of the
+// account
+func (c *Client) UpdatePublicKeys(ctx context.Context, keys string) error {
+	query := `mutation($input: UpdateUserSettingsInput) { updateUserSettings(input: $input) { id } }`
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"pubKey": keys},
+	}
+
+	var data struct {
+		UpdateUserSettings struct {
+			ID string `json:"id"`
+		} `json:"updateUserSettings"`
+	}
+
+	return c.doGraphQL(ctx, query, variables, &data)
+}
+
+// Secret is an account-level secret, referenced in environment variables as
+// {{ RUNPOD_SECRET_name }}. Its value is never returned by the API.
+type Secret struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ListSecrets lists the secrets of the account
+func (c *Client) ListSecrets(ctx context.Context) ([]Secret, error) {
+	var data struct {
+		Myself struct {
+			Secrets []Secret `json:"secrets"`
+		} `json:"myself"`
+	}
+
+	query := `query { myself { secrets { id name description } } }`
+	if err := c.doGraphQL(ctx, query, nil, &data); err != nil {
+		return nil, err
+	}
+
+	return data.Myself.Secrets, nil
+}
+
+// CreateSecret creates a new Secret
+func (c *Client) CreateSecret(ctx context.Context, name, value, description string) (*Secret, error) {
+	var data struct {
+		SecretCreate Secret `json:"secretCreate"`
+	}
+
+	query := `mutation($input: SecretCreateInput!) { secretCreate(input: $input) { id name description } }`
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"name": name, "value": value, "description": description},
+	}
+	if err := c.doGraphQL(ctx, query, variables, &data); err != nil {
+		return nil, err
+	}
+
+	return &data.SecretCreate, nil
+}
+
+// UpdateSecret replaces the value and description of a Secret
+func (c *Client) UpdateSecret(ctx context.Context, id, value, description string) (*Secret, error) {
+	var data struct {
+		SecretUpdate Secret `json:"secretUpdate"`
+	}
+
+	query := `mutation($input: SecretUpdateInput!) { secretUpdate(input: $input) { id name description } }`
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"id": id, "value": value, "description": description},
+	}
+	if err := c.doGraphQL(ctx, query, variables, &data); err != nil {
+		return nil, err
+	}
+
+	return &data.SecretUpdate, nil
+}
+
+// DeleteSecret deletes a Secret
+func (c *Client) DeleteSecret(ctx context.Context, id string) error {
+	var data struct {
+		SecretDelete interface{} `json:"secretDelete"`
+	}
+
+	query := `mutation($id: String!) { secretDelete(id: $id) }`
+	return c.doGraphQL(ctx, query, map[string]interface{}{"id": id}, &data)
+}
+
+// GPUType is the pricing of a GPU type, read from the GraphQL API since the
+// REST API does not list GPU types. Prices are in USD per GPU per hour and
+// are null where the GPU type is not offered.
+type GPUType struct {
+	ID                 string   `json:"id"`
+	SecurePrice        *float64 `json:"securePrice"`
+	CommunityPrice     *float64 `json:"communityPrice"`
+	SecureSpotPrice    *float64 `json:"secureSpotPrice"`
+	CommunitySpotPrice *float64 `json:"communitySpotPrice"`
+}
+
+// ListGPUTypes lists all GPU types with their prices
+func (c *Client) ListGPUTypes(ctx context.Context) ([]GPUType, error) {
+	var data struct {
+		GPUTypes []GPUType `json:"gpuTypes"`
+	}
+
+	query := `query { gpuTypes { id securePrice communityPrice secureSpotPrice communitySpotPrice } }`
+	if err := c.doGraphQL(ctx, query, nil, &data); err != nil {
+		return nil, err
+	}
+
+	return data.GPUTypes, nil
 }
 
 // CreatePod creates a new Pod
@@ -190,9 +324,9 @@ func (c *Client) CreatePod(ctx context.Context, input *PodCreateInput) (*Pod, er
 	return &pod, nil
 }
 
-// GetPod retrieves a Pod by ID
+// GetPod retrieves a Pod by ID, including its machine and network volume
 func (c *Client) GetPod(ctx context.Context, id string) (*Pod, error) {
-	resp, err := c.doRequest(ctx, "GET", "/pods/"+id, nil)
+	resp, err := c.doRequest(ctx, "GET", "/pods/"+id+"?includeMachine=true&includeNetworkVolume=true", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +342,7 @@ func (c *Client) GetPod(ctx context.Context, id string) (*Pod, error) {
 
 // UpdatePod updates a Pod (triggers reset)
 func (c *Client) UpdatePod(ctx context.Context, id string, input *PodUpdateInput) (*Pod, error) {
-	resp, err := c.doRequest(ctx, "PUT", "/pods/"+id, input)
+	resp, err := c.doRequest(ctx, "PATCH", "/pods/"+id, input)
 	if err != nil {
 		return nil, err
 	}
@@ -271,74 +405,6 @@ func (c *Client) StartPod(ctx context.Context, id string) error {
 	return nil
 }
 
-// Endpoint represents a RunPod Serverless Endpoint
-type Endpoint struct {
-	ID                  string                 `json:"id,omitempty"`
-	Name                string                 `json:"name,omitempty"`
-	TemplateId          string                 `json:"templateId,omitempty"`
-	ComputeType         string                 `json:"computeType,omitempty"`
-	GPUCount            int                    `json:"gpuCount,omitempty"`
-	VCPUCount           int                    `json:"vcpuCount,omitempty"`
-	GPUTypeIds          []string               `json:"gpuTypeIds,omitempty"`
-	CPUFlavorIds        []string               `json:"cpuFlavorIds,omitempty"`
-	DataCenterIds       []string               `json:"dataCenterIds,omitempty"`
-	NetworkVolumeId     string                 `json:"networkVolumeId,omitempty"`
-	WorkersMin          int                    `json:"workersMin,omitempty"`
-	WorkersMax          int                    `json:"workersMax,omitempty"`
-	IdleTimeout         int                    `json:"idleTimeout,omitempty"`
-	ExecutionTimeoutMs  int                    `json:"executionTimeoutMs,omitempty"`
-	ScalerType          string                 `json:"scalerType,omitempty"`
-	ScalerValue         int                    `json:"scalerValue,omitempty"`
-	AllowedCudaVersions []string               `json:"allowedCudaVersions,omitempty"`
-	Env                 map[string]string      `json:"env,omitempty"`
-	Flashboot           bool                   `json:"flashboot,omitempty"`
-	CreatedAt           string                 `json:"createdAt,omitempty"`
-	UserId              string                 `json:"userId,omitempty"`
-	Version             int                    `json:"version,omitempty"`
-	Template            map[string]interface{} `json:"template,omitempty"`
-}
-
-// EndpointCreateInput represents the input for creating an Endpoint
-type EndpointCreateInput struct {
-	Name                string   `json:"name,omitempty"`
-	TemplateId          string   `json:"templateId"`
-	ComputeType         string   `json:"computeType,omitempty"`
-	GPUCount            *int     `json:"gpuCount,omitempty"`
-	VCPUCount           *int     `json:"vcpuCount,omitempty"`
-	GPUTypeIds          []string `json:"gpuTypeIds,omitempty"`
-	CPUFlavorIds        []string `json:"cpuFlavorIds,omitempty"`
-	DataCenterIds       []string `json:"dataCenterIds,omitempty"`
-	NetworkVolumeId     string   `json:"networkVolumeId,omitempty"`
-	WorkersMin          *int     `json:"workersMin,omitempty"`
-	WorkersMax          *int     `json:"workersMax,omitempty"`
-	IdleTimeout         *int     `json:"idleTimeout,omitempty"`
-	ExecutionTimeoutMs  *int     `json:"executionTimeoutMs,omitempty"`
-	ScalerType          string   `json:"scalerType,omitempty"`
-	ScalerValue         *int     `json:"scalerValue,omitempty"`
-	AllowedCudaVersions []string `json:"allowedCudaVersions,omitempty"`
-	Flashboot           *bool    `json:"flashboot,omitempty"`
-}
-
-// EndpointUpdateInput represents the input for updating an Endpoint
-type EndpointUpdateInput struct {
-	Name                string   `json:"name,omitempty"`
-	TemplateId          string   `json:"templateId,omitempty"`
-	GPUCount            *int     `json:"gpuCount,omitempty"`
-	VCPUCount           *int     `json:"vcpuCount,omitempty"`
-	GPUTypeIds          []string `json:"gpuTypeIds,omitempty"`
-	CPUFlavorIds        []string `json:"cpuFlavorIds,omitempty"`
-	DataCenterIds       []string `json:"dataCenterIds,omitempty"`
-	NetworkVolumeId     string   `json:"networkVolumeId,omitempty"`
-	WorkersMin          *int     `json:"workersMin,omitempty"`
-	WorkersMax          *int     `json:"workersMax,omitempty"`
-	IdleTimeout         *int     `json:"idleTimeout,omitempty"`
-	ExecutionTimeoutMs  *int     `json:"executionTimeoutMs,omitempty"`
-	ScalerType          string   `json:"scalerType,omitempty"`
-	ScalerValue         *int     `json:"scalerValue,omitempty"`
-	AllowedCudaVersions []string `json:"allowedCudaVersions,omitempty"`
-	Flashboot           *bool    `json:"flashboot,omitempty"`
-}
-
 // CreateEndpoint creates a new Endpoint
 func (c *Client) CreateEndpoint(ctx context.Context, input *EndpointCreateInput) (*Endpoint, error) {
 	resp, err := c.doRequest(ctx, "POST", "/endpoints", input)
@@ -414,27 +480,6 @@ func (c *Client) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
 	return endpoints, nil
 }
 
-// NetworkVolume represents a RunPod Network Volume
-type NetworkVolume struct {
-	ID           string `json:"id,omitempty"`
-	Name         string `json:"name,omitempty"`
-	Size         int    `json:"size,omitempty"`
-	DataCenterId string `json:"dataCenterId,omitempty"`
-}
-
-// NetworkVolumeCreateInput represents the input for creating a Network Volume
-type NetworkVolumeCreateInput struct {
-	Name         string `json:"name"`
-	Size         int    `json:"size"`
-	DataCenterId string `json:"dataCenterId"`
-}
-
-// NetworkVolumeUpdateInput represents the input for updating a Network Volume
-type NetworkVolumeUpdateInput struct {
-	Name string `json:"name,omitempty"`
-	Size *int   `json:"size,omitempty"`
-}
-
 // CreateNetworkVolume creates a new Network Volume
 func (c *Client) CreateNetworkVolume(ctx context.Context, input *NetworkVolumeCreateInput) (*NetworkVolume, error) {
 	resp, err := c.doRequest(ctx, "POST", "/networkvolumes", input)
@@ -510,9 +555,74 @@ func (c *Client) ListNetworkVolumes(ctx context.Context) ([]NetworkVolume, error
 	return volumes, nil
 }
 
-// ListPods lists all Pods
-func (c *Client) ListPods(ctx context.Context) ([]Pod, error) {
-	resp, err := c.doRequest(ctx, "GET", "/pods", nil)
+// ListPodsFilter narrows the Pods returned by ListPods
+type ListPodsFilter struct {
+	// NetworkVolumeId limits the result to Pods with the Network Volume attached.
+	NetworkVolumeId string
+	// Name limits the result to Pods with the name.
+	Name string
+	// ComputeType limits the result to GPU or CPU Pods.
+	ComputeType string
+	// DesiredStatus limits the result to Pods with the status, e.g. RUNNING.
+	DesiredStatus string
+	// ImageName limits the result to Pods running the image.
+	ImageName string
+	// TemplateId limits the result to Pods created from the Template.
+	TemplateId string
+	// GPUTypeIds limits the result to Pods with any of the GPU types attached.
+	GPUTypeIds []string
+	// DataCenterIds limits the result to Pods in any of the data centers.
+	DataCenterIds []string
+	// IncludeWorkers also lists Pods which are Serverless workers.
+	IncludeWorkers bool
+	// IncludeDetails includes the machine and network volume of each Pod,
+	// as GetPod does.
+	IncludeDetails bool
+}
+
+// ListPods lists all Pods matching the filter, which may be nil
+func (c *Client) ListPods(ctx context.Context, filter *ListPodsFilter) ([]Pod, error) {
+	query := url.Values{}
+	if filter != nil {
+		if filter.NetworkVolumeId != "" {
+			query.Set("networkVolumeId", filter.NetworkVolumeId)
+		}
+		if filter.Name != "" {
+			query.Set("name", filter.Name)
+		}
+		if filter.ComputeType != "" {
+			query.Set("computeType", filter.ComputeType)
+		}
+		if filter.DesiredStatus != "" {
+			query.Set("desiredStatus", filter.DesiredStatus)
+		}
+		if filter.ImageName != "" {
+			query.Set("imageName", filter.ImageName)
+		}
+		if filter.TemplateId != "" {
+			query.Set("templateId", filter.TemplateId)
+		}
+		for _, id := range filter.GPUTypeIds {
+			query.Add("gpuTypeId", id)
+		}
+		for _, id := range filter.DataCenterIds {
+			query.Add("dataCenterId", id)
+		}
+		if filter.IncludeWorkers {
+			query.Set("includeWorkers", "true")
+		}
+		if filter.IncludeDetails {
+			query.Set("includeMachine", "true")
+			query.Set("includeNetworkVolume", "true")
+		}
+	}
+
+	path := "/pods"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -526,33 +636,38 @@ func (c *Client) ListPods(ctx context.Context) ([]Pod, error) {
 	return pods, nil
 }
 
-// Template represents a RunPod Template
-type Template struct {
-	ID                      string            `json:"id,omitempty"`
-	Name                    string            `json:"name,omitempty"`
-	ImageName               string            `json:"imageName,omitempty"`
-	Category                string            `json:"category,omitempty"`
-	ContainerDiskInGb       int               `json:"containerDiskInGb,omitempty"`
-	VolumeInGb              int               `json:"volumeInGb,omitempty"`
-	VolumeMountPath         string            `json:"volumeMountPath,omitempty"`
-	Ports                   []string          `json:"ports,omitempty"`
-	Env                     map[string]string `json:"env,omitempty"`
-	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
-	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
-	IsPublic                bool              `json:"isPublic,omitempty"`
-	IsRunpod                bool              `json:"isRunpod,omitempty"`
-	IsServerless            bool              `json:"isServerless,omitempty"`
-	Readme                  string            `json:"readme,omitempty"`
-	RuntimeInMin            int               `json:"runtimeInMin,omitempty"`
-	StartJupyter            bool              `json:"startJupyter,omitempty"`
-	StartSsh                bool              `json:"startSsh,omitempty"`
-	ContainerRegistryAuthId string            `json:"containerRegistryAuthId,omitempty"`
-	Earned                  float64           `json:"earned,omitempty"`
-}
-
-// ListTemplates lists all Templates
-func (c *Client) ListTemplates(ctx context.Context) ([]Template, error) {
-	resp, err := c.doRequest(ctx, "GET", "/templates", nil)
+// ListTemplatesFilter widens the Templates returned by ListTemplates
+type ListTemplatesFilter struct {
+	// IncludeEndpointBound also lists Templates used by Serverless Endpoints.
+	IncludeEndpointBound bool
+	// IncludePublic also lists public Templates of other users.
+	IncludePublic bool
+	// IncludeRunpod also lists Templates provided by RunPod.
+	IncludeRunpod bool
+}
+
+// ListTemplates lists the Templates of the account matching the filter, which
+// may be nil
+func (c *Client) ListTemplates(ctx context.Context, filter *ListTemplatesFilter) ([]Template, error) {
+	query := url.Values{}
+	if filter != nil {
+		if filter.IncludeEndpointBound {
+			query.Set("includeEndpointBoundTemplates", "true")
+		}
+		if filter.IncludePublic {
+			query.Set("includePublicTemplates", "true")
+		}
+		if filter.IncludeRunpod {
+			query.Set("includeRunpodTemplates", "true")
+		}
+	}
+
+	path := "/templates"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -565,3 +680,84 @@ func (c *Client) ListTemplates(ctx context.Context) ([]Template, error) {
 
 	return templates, nil
 }
+
+// CreateTemplate creates a new Template
+func (c *Client) CreateTemplate(ctx context.Context, input *TemplateCreateInput) (*Template, error) {
+	resp, err := c.doRequest(ctx, "POST", "/templates", input)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var template Template
+	if err := json.NewDecoder(resp.Body).Decode(&template); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return &template, nil
+}
+
+// GetTemplate retrieves a Template by ID
+func (c *Client) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	resp, err := c.doRequest(ctx, "GET", "/templates/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var template Template
+	if err := json.NewDecoder(resp.Body).Decode(&template); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return &template, nil
+}
+
+// UpdateTemplate updates a Template
+func (c *Client) UpdateTemplate(ctx context.Context, id string, input *TemplateUpdateInput) (*Template, error) {
+	resp, err := c.doRequest(ctx, "PATCH", "/templates/"+id, input)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var template Template
+	if err := json.NewDecoder(resp.Body).Decode(&template); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return &template, nil
+}
+
+// DeleteTemplate deletes a Template
+func (c *Client) DeleteTemplate(ctx context.Context, id string) error {
+	resp, err := c.doRequest(ctx, "DELETE", "/templates/"+id, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// GetNetworkVolumeBilling retrieves the hourly billing records of a Network
+// Volume since the given time
+func (c *Client) GetNetworkVolumeBilling(ctx context.Context, id string, since time.Time) ([]BillingRecord, error) {
+	query := url.Values{}
+	query.Set("networkVolumeId", id)
+	query.Set("bucketSize", "hour")
+	query.Set("startTime", since.UTC().Format(time.RFC3339))
+
+	resp, err := c.doRequest(ctx, "GET", "/billing/networkvolumes?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var records []BillingRecord
+	if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordedRequest is a request the client sent to the test server.
+type recordedRequest struct {
+	method string
+	path   string
+	query  []string
+	body   []byte
+}
+
+// TestClientRequestsMatchOpenAPI calls every method of Client against a test
+// server and fails when a REST request uses a path, method, query parameter
+// or request body field that openapi.json does not define. GraphQL requests
+// are not covered by the spec and are skipped.
+func TestClientRequestsMatchOpenAPI(t *testing.T) {
+	spec := loadOpenAPISpec(t)
+
+	var mu sync.Mutex
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var query []string
+		for k := range r.URL.Query() {
+			query = append(query, k)
+		}
+		sort.Strings(query)
+
+		mu.Lock()
+		requests = append(requests, recordedRequest{method: r.Method, path: r.URL.Path, query: query, body: body})
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("null"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test")
+	client.BaseURL = server.URL
+	client.GraphQLURL = server.URL + "/graphql"
+
+	ctx := context.Background()
+	clientValue := reflect.ValueOf(client)
+	for i := 0; i < clientValue.NumMethod(); i++ {
+		method := clientValue.Type().Method(i)
+		args, ok := requestArgs(ctx, method.Type)
+		if !ok {
+			t.Errorf("%s: unsupported argument types, add them to requestArgs", method.Name)
+			continue
+		}
+
+		requests = nil
+		clientValue.Method(i).Call(args)
+
+		for _, req := range requests {
+			if req.path == "/graphql" {
+				continue
+			}
+			checkRequest(t, spec, method.Name, req)
+		}
+	}
+}
+
+// requestArgs builds arguments for a Client method, filling inputs and
+// filters completely so that every field they can send is sent.
+func requestArgs(ctx context.Context, method reflect.Type) ([]reflect.Value, bool) {
+	// The first input is the receiver.
+	var args []reflect.Value
+	for i := 1; i < method.NumIn(); i++ {
+		in := method.In(i)
+		switch {
+		case in == reflect.TypeOf((*context.Context)(nil)).Elem():
+			args = append(args, reflect.ValueOf(ctx))
+		case in == reflect.TypeOf(time.Time{}):
+			args = append(args, reflect.ValueOf(time.Now()))
+		case in.Kind() == reflect.String:
+			args = append(args, reflect.ValueOf("abc123").Convert(in))
+		case in.Kind() == reflect.Ptr && in.Elem().Kind() == reflect.Struct:
+			v := reflect.New(in.Elem())
+			fillValue(v.Elem())
+			args = append(args, v)
+		default:
+			return nil, false
+		}
+	}
+	return args, true
+}
+
+// fillValue sets v, and every field, element or pointer it contains, to a
+// non-zero value.
+func fillValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillValue(v.Elem())
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillValue(v.Index(0))
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		key := reflect.New(v.Type().Key()).Elem()
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fillValue(key)
+		fillValue(elem)
+		v.SetMapIndex(key, elem)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fillValue(v.Field(i))
+			}
+		}
+	}
+}
+
+// checkRequest checks a request against the operation openapi.json defines
+// for its path and method.
+func checkRequest(t *testing.T, spec *openAPISpec, name string, req recordedRequest) {
+	t.Helper()
+
+	where := name + ": " + req.method + " " + req.path
+
+	path, ops := matchOpenAPIPath(spec, req.path)
+	if ops == nil {
+		t.Errorf("%s: path not defined in openapi.json", where)
+		return
+	}
+
+	raw, ok := ops[strings.ToLower(req.method)]
+	if !ok {
+		t.Errorf("%s: method not defined for %s in openapi.json", where, path)
+		return
+	}
+
+	var op openAPIOperation
+	if err := json.Unmarshal(raw, &op); err != nil {
+		t.Fatalf("%s: error decoding operation: %s", where, err)
+	}
+
+	params := map[string]bool{}
+	for _, p := range op.Parameters {
+		if p.In == "query" {
+			params[p.Name] = true
+		}
+	}
+	for _, q := range req.query {
+		if !params[q] {
+			t.Errorf("%s: query parameter %s not defined in openapi.json", where, q)
+		}
+	}
+
+	if len(req.body) == 0 {
+		return
+	}
+	if op.RequestBody == nil {
+		t.Errorf("%s: sends a body, but openapi.json defines none", where)
+		return
+	}
+
+	content, ok := op.RequestBody.Content["application/json"]
+	if !ok || content.Schema == nil {
+		t.Errorf("%s: openapi.json defines no JSON request body", where)
+		return
+	}
+	schema := content.Schema
+	if schema.Ref != "" {
+		schema = spec.Components.Schemas[schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]]
+		if schema == nil {
+			t.Errorf("%s: unresolved request body reference", where)
+			return
+		}
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(req.body, &fields); err != nil {
+		t.Errorf("%s: request body is not a JSON object: %s", where, err)
+		return
+	}
+	for field := range fields {
+		if _, ok := schema.Properties[field]; !ok {
+			t.Errorf("%s: request body field %s not defined in openapi.json", where, field)
+		}
+	}
+}
+
+// matchOpenAPIPath returns the path template of openapi.json matching a
+// request path, e.g. /pods/{podId} for /pods/abc123, and its operations.
+func matchOpenAPIPath(spec *openAPISpec, path string) (string, map[string]json.RawMessage) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for template, ops := range spec.Paths {
+		templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+		if len(templateSegments) != len(segments) {
+			continue
+		}
+
+		matched := true
+		for i, s := range templateSegments {
+			if s != segments[i] && !strings.HasPrefix(s, "{") {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return template, ops
+		}
+	}
+	return "", nil
+}
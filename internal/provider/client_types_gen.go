@@ -0,0 +1,357 @@
+// Code generated by apigen from openapi.json; DO NOT EDIT.
+
+package provider
+
+// BillingRecord is generated from the BillingRecord schema.
+type BillingRecord struct {
+	Amount            float64 `json:"amount,omitempty"`
+	DiskSpaceBilledGb int     `json:"diskSpaceBilledGb,omitempty"`
+	EndpointId        string  `json:"endpointId,omitempty"`
+	GPUTypeId         string  `json:"gpuTypeId,omitempty"`
+	PodId             string  `json:"podId,omitempty"`
+	Time              string  `json:"time,omitempty"`
+	TimeBilledMs      int     `json:"timeBilledMs,omitempty"`
+}
+
+// Endpoint is generated from the Endpoint schema.
+type Endpoint struct {
+	AllowedCudaVersions []string          `json:"allowedCudaVersions,omitempty"`
+	ComputeType         string            `json:"computeType,omitempty"`
+	CreatedAt           string            `json:"createdAt,omitempty"`
+	DataCenterIds       []string          `json:"dataCenterIds,omitempty"`
+	Env                 map[string]string `json:"env,omitempty"`
+	ExecutionTimeoutMs  *int              `json:"executionTimeoutMs,omitempty"`
+	Flashboot           *bool             `json:"flashboot,omitempty"`
+	GPUCount            *int              `json:"gpuCount,omitempty"`
+	GPUTypeIds          []string          `json:"gpuTypeIds,omitempty"`
+	ID                  string            `json:"id,omitempty"`
+	IdleTimeout         *int              `json:"idleTimeout,omitempty"`
+	InstanceIds         []string          `json:"instanceIds,omitempty"`
+	Name                string            `json:"name,omitempty"`
+	NetworkVolumeId     string            `json:"networkVolumeId,omitempty"`
+	ScalerType          string            `json:"scalerType,omitempty"`
+	ScalerValue         *int              `json:"scalerValue,omitempty"`
+	Template            *Template         `json:"template,omitempty"`
+	TemplateId          string            `json:"templateId,omitempty"`
+	UserId              string            `json:"userId,omitempty"`
+	Version             *int              `json:"version,omitempty"`
+	Workers             []Pod             `json:"workers,omitempty"`
+	WorkersMax          *int              `json:"workersMax,omitempty"`
+	WorkersMin          *int              `json:"workersMin,omitempty"`
+}
+
+// EndpointCreateInput is generated from the EndpointCreateInput schema.
+type EndpointCreateInput struct {
+	AllowedCudaVersions []string `json:"allowedCudaVersions,omitempty"`
+	ComputeType         string   `json:"computeType,omitempty"`
+	CPUFlavorIds        []string `json:"cpuFlavorIds,omitempty"`
+	DataCenterIds       []string `json:"dataCenterIds,omitempty"`
+	ExecutionTimeoutMs  *int     `json:"executionTimeoutMs,omitempty"`
+	Flashboot           *bool    `json:"flashboot,omitempty"`
+	GPUCount            *int     `json:"gpuCount,omitempty"`
+	GPUTypeIds          []string `json:"gpuTypeIds,omitempty"`
+	IdleTimeout         *int     `json:"idleTimeout,omitempty"`
+	Name                string   `json:"name,omitempty"`
+	NetworkVolumeId     string   `json:"networkVolumeId,omitempty"`
+	ScalerType          string   `json:"scalerType,omitempty"`
+	ScalerValue         *int     `json:"scalerValue,omitempty"`
+	TemplateId          string   `json:"templateId"`
+	VCPUCount           *int     `json:"vcpuCount,omitempty"`
+	WorkersMax          *int     `json:"workersMax,omitempty"`
+	WorkersMin          *int     `json:"workersMin,omitempty"`
+}
+
+// EndpointUpdateInput is generated from the EndpointUpdateInput schema.
+type EndpointUpdateInput struct {
+	AllowedCudaVersions []string `json:"allowedCudaVersions,omitempty"`
+	CPUFlavorIds        []string `json:"cpuFlavorIds,omitempty"`
+	DataCenterIds       []string `json:"dataCenterIds,omitempty"`
+	ExecutionTimeoutMs  *int     `json:"executionTimeoutMs,omitempty"`
+	Flashboot           *bool    `json:"flashboot,omitempty"`
+	GPUCount            *int     `json:"gpuCount,omitempty"`
+	GPUTypeIds          []string `json:"gpuTypeIds,omitempty"`
+	IdleTimeout         *int     `json:"idleTimeout,omitempty"`
+	Name                string   `json:"name,omitempty"`
+	NetworkVolumeId     string   `json:"networkVolumeId,omitempty"`
+	ScalerType          string   `json:"scalerType,omitempty"`
+	ScalerValue         *int     `json:"scalerValue,omitempty"`
+	TemplateId          string   `json:"templateId,omitempty"`
+	VCPUCount           *int     `json:"vcpuCount,omitempty"`
+	WorkersMax          *int     `json:"workersMax,omitempty"`
+	WorkersMin          *int     `json:"workersMin,omitempty"`
+}
+
+// NetworkVolume is generated from the NetworkVolume schema.
+type NetworkVolume struct {
+	DataCenterId string `json:"dataCenterId,omitempty"`
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Size         int    `json:"size,omitempty"`
+}
+
+// NetworkVolumeCreateInput is generated from the NetworkVolumeCreateInput schema.
+type NetworkVolumeCreateInput struct {
+	DataCenterId string `json:"dataCenterId"`
+	Name         string `json:"name"`
+	Size         int    `json:"size"`
+}
+
+// NetworkVolumeUpdateInput is generated from the NetworkVolumeUpdateInput schema.
+type NetworkVolumeUpdateInput struct {
+	Name string `json:"name,omitempty"`
+	Size *int   `json:"size,omitempty"`
+}
+
+// Pod is generated from the Pod schema.
+type Pod struct {
+	AdjustedCostPerHr       *float64          `json:"adjustedCostPerHr,omitempty"`
+	AiApiId                 string            `json:"aiApiId,omitempty"`
+	ConsumerUserId          string            `json:"consumerUserId,omitempty"`
+	ContainerDiskInGb       *int              `json:"containerDiskInGb,omitempty"`
+	ContainerRegistryAuthId string            `json:"containerRegistryAuthId,omitempty"`
+	CostPerHr               *float64          `json:"costPerHr,omitempty"`
+	CPUFlavorId             string            `json:"cpuFlavorId,omitempty"`
+	DesiredStatus           string            `json:"desiredStatus,omitempty"`
+	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
+	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
+	EndpointId              string            `json:"endpointId,omitempty"`
+	Env                     map[string]string `json:"env,omitempty"`
+	GPU                     *PodGPU           `json:"gpu,omitempty"`
+	ID                      string            `json:"id,omitempty"`
+	Image                   string            `json:"image,omitempty"`
+	Interruptible           *bool             `json:"interruptible,omitempty"`
+	LastStartedAt           string            `json:"lastStartedAt,omitempty"`
+	LastStatusChange        string            `json:"lastStatusChange,omitempty"`
+	Locked                  *bool             `json:"locked,omitempty"`
+	Machine                 *PodMachine       `json:"machine,omitempty"`
+	MachineId               string            `json:"machineId,omitempty"`
+	MemoryInGb              *float64          `json:"memoryInGb,omitempty"`
+	Name                    string            `json:"name,omitempty"`
+	NetworkVolume           *PodNetworkVolume `json:"networkVolume,omitempty"`
+	PortMappings            map[string]int    `json:"portMappings,omitempty"`
+	Ports                   []string          `json:"ports,omitempty"`
+	PublicIp                string            `json:"publicIp,omitempty"`
+	SavingsPlans            []SavingsPlan     `json:"savingsPlans,omitempty"`
+	SlsVersion              *int              `json:"slsVersion,omitempty"`
+	TemplateId              string            `json:"templateId,omitempty"`
+	VCPUCount               *float64          `json:"vcpuCount,omitempty"`
+	VolumeEncrypted         *bool             `json:"volumeEncrypted,omitempty"`
+	VolumeInGb              *int              `json:"volumeInGb,omitempty"`
+	VolumeMountPath         string            `json:"volumeMountPath,omitempty"`
+}
+
+// PodCreateInput is generated from the PodCreateInput schema.
+type PodCreateInput struct {
+	AllowedCudaVersions     []string          `json:"allowedCudaVersions,omitempty"`
+	CloudType               string            `json:"cloudType,omitempty"`
+	ComputeType             string            `json:"computeType,omitempty"`
+	ContainerDiskInGb       *int              `json:"containerDiskInGb,omitempty"`
+	ContainerRegistryAuthId string            `json:"containerRegistryAuthId,omitempty"`
+	CountryCodes            []string          `json:"countryCodes,omitempty"`
+	CPUFlavorIds            []string          `json:"cpuFlavorIds,omitempty"`
+	CPUFlavorPriority       string            `json:"cpuFlavorPriority,omitempty"`
+	DataCenterIds           []string          `json:"dataCenterIds,omitempty"`
+	DataCenterPriority      string            `json:"dataCenterPriority,omitempty"`
+	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
+	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
+	Env                     map[string]string `json:"env,omitempty"`
+	GlobalNetworking        *bool             `json:"globalNetworking,omitempty"`
+	GPUCount                *int              `json:"gpuCount,omitempty"`
+	GPUTypeIds              []string          `json:"gpuTypeIds,omitempty"`
+	GPUTypePriority         string            `json:"gpuTypePriority,omitempty"`
+	ImageName               string            `json:"imageName,omitempty"`
+	Interruptible           *bool             `json:"interruptible,omitempty"`
+	Locked                  *bool             `json:"locked,omitempty"`
+	MinDiskBandwidthMBps    *float64          `json:"minDiskBandwidthMBps,omitempty"`
+	MinDownloadMbps         *float64          `json:"minDownloadMbps,omitempty"`
+	MinRAMPerGPU            *int              `json:"minRAMPerGPU,omitempty"`
+	MinUploadMbps           *float64          `json:"minUploadMbps,omitempty"`
+	MinVCPUPerGPU           *int              `json:"minVCPUPerGPU,omitempty"`
+	Name                    string            `json:"name,omitempty"`
+	NetworkVolumeId         string            `json:"networkVolumeId,omitempty"`
+	Ports                   []string          `json:"ports,omitempty"`
+	SupportPublicIp         *bool             `json:"supportPublicIp,omitempty"`
+	TemplateId              string            `json:"templateId,omitempty"`
+	VCPUCount               *int              `json:"vcpuCount,omitempty"`
+	VolumeInGb              *int              `json:"volumeInGb,omitempty"`
+	VolumeMountPath         string            `json:"volumeMountPath,omitempty"`
+}
+
+// PodGPU is generated from the inline Pod.gpu schema.
+type PodGPU struct {
+	CommunityPrice     *float64 `json:"communityPrice,omitempty"`
+	CommunitySpotPrice *float64 `json:"communitySpotPrice,omitempty"`
+	Count              *int     `json:"count,omitempty"`
+	DisplayName        string   `json:"displayName,omitempty"`
+	ID                 string   `json:"id,omitempty"`
+	OneMonthPrice      *float64 `json:"oneMonthPrice,omitempty"`
+	OneWeekPrice       *float64 `json:"oneWeekPrice,omitempty"`
+	SecurePrice        *float64 `json:"securePrice,omitempty"`
+	SecureSpotPrice    *float64 `json:"secureSpotPrice,omitempty"`
+	SixMonthPrice      *float64 `json:"sixMonthPrice,omitempty"`
+	ThreeMonthPrice    *float64 `json:"threeMonthPrice,omitempty"`
+}
+
+// PodMachine is generated from the inline Pod.machine schema.
+type PodMachine struct {
+	CostPerHr            *float64           `json:"costPerHr,omitempty"`
+	CPUCount             *int               `json:"cpuCount,omitempty"`
+	CPUType              *PodMachineCPUType `json:"cpuType,omitempty"`
+	CPUTypeId            string             `json:"cpuTypeId,omitempty"`
+	CurrentPricePerGpu   *float64           `json:"currentPricePerGpu,omitempty"`
+	DataCenterId         string             `json:"dataCenterId,omitempty"`
+	DiskThroughputMBps   *int               `json:"diskThroughputMBps,omitempty"`
+	GPUAvailable         *int               `json:"gpuAvailable,omitempty"`
+	GPUDisplayName       string             `json:"gpuDisplayName,omitempty"`
+	GPUType              *PodMachineGPUType `json:"gpuType,omitempty"`
+	GPUTypeId            string             `json:"gpuTypeId,omitempty"`
+	Location             string             `json:"location,omitempty"`
+	MaintenanceEnd       string             `json:"maintenanceEnd,omitempty"`
+	MaintenanceNote      string             `json:"maintenanceNote,omitempty"`
+	MaintenanceStart     string             `json:"maintenanceStart,omitempty"`
+	MaxDownloadSpeedMbps *int               `json:"maxDownloadSpeedMbps,omitempty"`
+	MaxUploadSpeedMbps   *int               `json:"maxUploadSpeedMbps,omitempty"`
+	MinPodGpuCount       *int               `json:"minPodGpuCount,omitempty"`
+	Note                 string             `json:"note,omitempty"`
+	SecureCloud          *bool              `json:"secureCloud,omitempty"`
+	SupportPublicIp      *bool              `json:"supportPublicIp,omitempty"`
+}
+
+// PodMachineCPUType is generated from the inline Pod.machine.cpuType schema.
+type PodMachineCPUType struct {
+	Cores          *float64 `json:"cores,omitempty"`
+	DisplayName    string   `json:"displayName,omitempty"`
+	GroupId        string   `json:"groupId,omitempty"`
+	ID             string   `json:"id,omitempty"`
+	ThreadsPerCore *float64 `json:"threadsPerCore,omitempty"`
+}
+
+// PodMachineGPUType is generated from the inline Pod.machine.gpuType schema.
+type PodMachineGPUType struct {
+	CommunityPrice     *float64 `json:"communityPrice,omitempty"`
+	CommunitySpotPrice *float64 `json:"communitySpotPrice,omitempty"`
+	Count              *int     `json:"count,omitempty"`
+	DisplayName        string   `json:"displayName,omitempty"`
+	ID                 string   `json:"id,omitempty"`
+	OneMonthPrice      *float64 `json:"oneMonthPrice,omitempty"`
+	OneWeekPrice       *float64 `json:"oneWeekPrice,omitempty"`
+	SecurePrice        *float64 `json:"securePrice,omitempty"`
+	SecureSpotPrice    *float64 `json:"secureSpotPrice,omitempty"`
+	SixMonthPrice      *float64 `json:"sixMonthPrice,omitempty"`
+	ThreeMonthPrice    *float64 `json:"threeMonthPrice,omitempty"`
+}
+
+// PodNetworkVolume is generated from the inline Pod.networkVolume schema.
+type PodNetworkVolume struct {
+	DataCenterId string `json:"dataCenterId,omitempty"`
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Size         *int   `json:"size,omitempty"`
+}
+
+// PodUpdateInPlaceInput is generated from the PodUpdateInPlaceInput schema.
+type PodUpdateInPlaceInput struct {
+	Locked *bool  `json:"locked,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// PodUpdateInput is generated from the PodUpdateInput schema.
+type PodUpdateInput struct {
+	ContainerDiskInGb       *int              `json:"containerDiskInGb,omitempty"`
+	ContainerRegistryAuthId string            `json:"containerRegistryAuthId,omitempty"`
+	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
+	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
+	Env                     map[string]string `json:"env,omitempty"`
+	GlobalNetworking        *bool             `json:"globalNetworking,omitempty"`
+	ImageName               string            `json:"imageName,omitempty"`
+	Locked                  *bool             `json:"locked,omitempty"`
+	Name                    string            `json:"name,omitempty"`
+	Ports                   []string          `json:"ports,omitempty"`
+	VolumeInGb              *int              `json:"volumeInGb,omitempty"`
+	VolumeMountPath         string            `json:"volumeMountPath,omitempty"`
+}
+
+// SavingsPlan is generated from the SavingsPlan schema.
+type SavingsPlan struct {
+	CostPerHr float64 `json:"costPerHr,omitempty"`
+	EndTime   string  `json:"endTime,omitempty"`
+	GPUTypeId string  `json:"gpuTypeId,omitempty"`
+	ID        string  `json:"id,omitempty"`
+	PodId     string  `json:"podId,omitempty"`
+	StartTime string  `json:"startTime,omitempty"`
+}
+
+// Template is generated from the Template schema.
+type Template struct {
+	Category                string            `json:"category,omitempty"`
+	ContainerDiskInGb       int               `json:"containerDiskInGb,omitempty"`
+	ContainerRegistryAuthId string            `json:"containerRegistryAuthId,omitempty"`
+	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
+	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
+	Earned                  float64           `json:"earned,omitempty"`
+	Env                     map[string]string `json:"env,omitempty"`
+	ID                      string            `json:"id,omitempty"`
+	ImageName               string            `json:"imageName,omitempty"`
+	IsPublic                bool              `json:"isPublic,omitempty"`
+	IsRunpod                bool              `json:"isRunpod,omitempty"`
+	IsServerless            bool              `json:"isServerless,omitempty"`
+	Name                    string            `json:"name,omitempty"`
+	Ports                   []string          `json:"ports,omitempty"`
+	Readme                  string            `json:"readme,omitempty"`
+	RuntimeInMin            int               `json:"runtimeInMin,omitempty"`
+	VolumeInGb              int               `json:"volumeInGb,omitempty"`
+	VolumeMountPath         string            `json:"volumeMountPath,omitempty"`
+}
+
+// TemplateCreateInput is generated from the TemplateCreateInput schema.
+type TemplateCreateInput struct {
+	Category                string            `json:"category,omitempty"`
+	ContainerDiskInGb       *int              `json:"containerDiskInGb,omitempty"`
+	ContainerRegistryAuthId string            `json:"containerRegistryAuthId,omitempty"`
+	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
+	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
+	Env                     map[string]string `json:"env,omitempty"`
+	ImageName               string            `json:"imageName"`
+	IsPublic                *bool             `json:"isPublic,omitempty"`
+	IsServerless            *bool             `json:"isServerless,omitempty"`
+	Name                    string            `json:"name"`
+	Ports                   []string          `json:"ports,omitempty"`
+	Readme                  string            `json:"readme,omitempty"`
+	VolumeInGb              *int              `json:"volumeInGb,omitempty"`
+	VolumeMountPath         string            `json:"volumeMountPath,omitempty"`
+}
+
+// TemplateUpdateInput is generated from the TemplateUpdateInput schema.
+type TemplateUpdateInput struct {
+	ContainerDiskInGb       *int              `json:"containerDiskInGb,omitempty"`
+	ContainerRegistryAuthId string            `json:"containerRegistryAuthId,omitempty"`
+	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
+	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
+	Env                     map[string]string `json:"env,omitempty"`
+	ImageName               string            `json:"imageName,omitempty"`
+	IsPublic                *bool             `json:"isPublic,omitempty"`
+	Name                    string            `json:"name,omitempty"`
+	Ports                   []string          `json:"ports,omitempty"`
+	Readme                  string            `json:"readme,omitempty"`
+	VolumeInGb              *int              `json:"volumeInGb,omitempty"`
+	VolumeMountPath         string            `json:"volumeMountPath,omitempty"`
+}
+
+// apiSchemaTypes maps each generated component schema to its Go type.
+var apiSchemaTypes = map[string]interface{}{
+	"BillingRecord":            BillingRecord{},
+	"Endpoint":                 Endpoint{},
+	"EndpointCreateInput":      EndpointCreateInput{},
+	"EndpointUpdateInput":      EndpointUpdateInput{},
+	"NetworkVolume":            NetworkVolume{},
+	"NetworkVolumeCreateInput": NetworkVolumeCreateInput{},
+	"NetworkVolumeUpdateInput": NetworkVolumeUpdateInput{},
+	"Pod":                      Pod{},
+	"PodCreateInput":           PodCreateInput{},
+	"PodUpdateInPlaceInput":    PodUpdateInPlaceInput{},
+	"PodUpdateInput":           PodUpdateInput{},
+	"SavingsPlan":              SavingsPlan{},
+	"Template":                 Template{},
+	"TemplateCreateInput":      TemplateCreateInput{},
+	"TemplateUpdateInput":      TemplateUpdateInput{},
+}
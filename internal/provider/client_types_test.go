@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"terraform-provider-runpod/internal/apispec"
+)
+
+// openAPISchema is the subset of an OpenAPI schema object checked against the
+// generated client types.
+type openAPISchema struct {
+	Ref        string                    `json:"$ref"`
+	Type       string                    `json:"type"`
+	Required   []string                  `json:"required"`
+	Properties map[string]*openAPISchema `json:"properties"`
+	Items      *openAPISchema            `json:"items"`
+}
+
+// openAPIOperation is the subset of an OpenAPI operation object checked
+// against the requests made by the client.
+type openAPIOperation struct {
+	Parameters []struct {
+		Name string `json:"name"`
+		In   string `json:"in"`
+	} `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *openAPISchema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+}
+
+// openAPISpec is the subset of openapi.json checked by the tests.
+type openAPISpec struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+// loadOpenAPISpec reads the spec the client types are generated from,
+// including the overlay.
+func loadOpenAPISpec(t *testing.T) *openAPISpec {
+	t.Helper()
+
+	raw, err := apispec.Load("../../openapi.json", "../../openapi.overlay.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var spec openAPISpec
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		t.Fatalf("error decoding openapi.json: %s", err)
+	}
+	return &spec
+}
+
+// TestClientTypesMatchOpenAPI fails when openapi.json and the client types
+// disagree. Run `go generate ./...` after updating the spec to fix it.
+func TestClientTypesMatchOpenAPI(t *testing.T) {
+	spec := loadOpenAPISpec(t)
+
+	for name, value := range apiSchemaTypes {
+		s, ok := spec.Components.Schemas[name]
+		if !ok {
+			t.Errorf("%s: schema no longer exists in openapi.json", name)
+			continue
+		}
+		checkSchemaType(t, spec.Components.Schemas, name, s, reflect.TypeOf(value))
+	}
+}
+
+func checkSchemaType(t *testing.T, schemas map[string]*openAPISchema, where string, s *openAPISchema, typ reflect.Type) {
+	t.Helper()
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if s.Ref != "" {
+		s = schemas[s.Ref[strings.LastIndex(s.Ref, "/")+1:]]
+		if s == nil {
+			t.Errorf("%s: unresolved reference", where)
+			return
+		}
+	}
+
+	switch s.Type {
+	case "string":
+		expectKind(t, where, typ, reflect.String)
+	case "integer":
+		expectKind(t, where, typ, reflect.Int)
+	case "number":
+		expectKind(t, where, typ, reflect.Float64)
+	case "boolean":
+		expectKind(t, where, typ, reflect.Bool)
+	case "array":
+		if expectKind(t, where, typ, reflect.Slice) && s.Items != nil {
+			checkSchemaType(t, schemas, where+"[]", s.Items, typ.Elem())
+		}
+	case "object":
+		if len(s.Properties) == 0 {
+			if expectKind(t, where, typ, reflect.Map) && s.Items != nil {
+				checkSchemaType(t, schemas, where+"{}", s.Items, typ.Elem())
+			}
+			return
+		}
+		if expectKind(t, where, typ, reflect.Struct) {
+			checkStructFields(t, schemas, where, s, typ)
+		}
+	}
+}
+
+func checkStructFields(t *testing.T, schemas map[string]*openAPISchema, where string, s *openAPISchema, typ reflect.Type) {
+	t.Helper()
+
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		fields[name] = f
+	}
+
+	var props []string
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	for _, prop := range props {
+		f, ok := fields[prop]
+		if !ok {
+			t.Errorf("%s.%s: defined in openapi.json but missing from %s", where, prop, typ.Name())
+			continue
+		}
+		delete(fields, prop)
+
+		omitempty := strings.HasSuffix(f.Tag.Get("json"), ",omitempty")
+		if required[prop] == omitempty {
+			t.Errorf("%s.%s: required is %t but omitempty is %t", where, prop, required[prop], omitempty)
+		}
+
+		checkSchemaType(t, schemas, where+"."+prop, s.Properties[prop], f.Type)
+	}
+
+	for name := range fields {
+		t.Errorf("%s.%s: present in %s but not defined in openapi.json", where, name, typ.Name())
+	}
+}
+
+func expectKind(t *testing.T, where string, typ reflect.Type, kind reflect.Kind) bool {
+	t.Helper()
+
+	if typ.Kind() != kind {
+		t.Errorf("%s: expected Go kind %s, got %s", where, kind, typ.Kind())
+		return false
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// defaultProfile is the profile read from the top level of the runpodctl
+// configuration file.
+const defaultProfile = "default"
+
+// readAPIKeyFile returns the API key stored in a file, ignoring surrounding
+// whitespace such as a trailing newline.
+func readAPIKeyFile(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading API key file: %w", err)
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+// runAPIKeyCommand runs a credential helper and returns the API key it
+// prints to standard output. The command is run directly, not by a shell.
+func runAPIKeyCommand(ctx context.Context, args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("API key command is empty")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error running API key command %q: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// runpodctlConfigPath returns the path of the configuration file written by
+// `runpodctl config`.
+func runpodctlConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding home directory: %w", err)
+	}
+	return filepath.Join(home, ".runpod", "config.toml"), nil
+}
+
+// readProfileAPIKey returns the API key of a profile in the runpodctl
+// configuration file. The default profile is the top-level apikey written by
+// runpodctl; other profiles are tables of the same name, e.g.
+//
+//	apikey = "..."
+//
+//	[research]
+//	apikey = "..."
+func readProfileAPIKey(path, profile string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	var config map[string]interface{}
+	if _, err := toml.Decode(string(raw), &config); err != nil {
+		return "", fmt.Errorf("error decoding %s: %w", path, err)
+	}
+
+	if profile != defaultProfile {
+		table, ok := config[profile].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("profile %q not found in %s", profile, path)
+		}
+		config = table
+	}
+
+	apiKey, _ := config["apikey"].(string)
+	if apiKey == "" {
+		return "", fmt.Errorf("profile %q in %s does not contain an apikey", profile, path)
+	}
+	return apiKey, nil
+}
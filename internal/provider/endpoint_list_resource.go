@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ list.ListResource = &EndpointListResource{}
+var _ list.ListResourceWithConfigure = &EndpointListResource{}
+
+func NewEndpointListResource() list.ListResource {
+	return &EndpointListResource{}
+}
+
+// EndpointListResource lists Serverless Endpoints for terraform query.
+// Metadata and Configure are those of the managed resource.
+type EndpointListResource struct {
+	EndpointResource
+}
+
+// EndpointListResourceModel describes the list resource data model.
+type EndpointListResourceModel struct {
+	Name       types.String `tfsdk:"name"`
+	TemplateId types.String `tfsdk:"template_id"`
+}
+
+func (r *EndpointListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Serverless Endpoints of the account, optionally filtered, for `terraform query`. " +
+			"The API has no filters for Endpoints, so they are applied by the provider.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "List only Endpoints with this name. The provider's `name_prefix` is prepended.",
+				Optional:            true,
+			},
+			"template_id": schema.StringAttribute{
+				MarkdownDescription: "List only Endpoints using this Template.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *EndpointListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	var config EndpointListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	tflog.Debug(ctx, "Listing Endpoints")
+
+	endpoints, err := r.client.ListEndpoints(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list endpoints, got error: %s", err))
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	resp.Results = func(push func(list.ListResult) bool) {
+		count := 0
+		for _, endpoint := range endpoints {
+			if !config.Name.IsNull() && endpoint.Name != r.config.prefixedName(config.Name.ValueString()) {
+				continue
+			}
+			if !config.TemplateId.IsNull() && endpoint.TemplateId != config.TemplateId.ValueString() {
+				continue
+			}
+			if limitReached(req, count) {
+				return
+			}
+			count++
+
+			var data EndpointResourceModel
+			result := listResult(ctx, req, &r.EndpointResource, endpoint.ID, endpoint.Name, &data, func() diag.Diagnostics {
+				return r.updateStateFromEndpoint(ctx, &data, &endpoint)
+			})
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
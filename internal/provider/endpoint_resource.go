@@ -3,12 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -17,6 +22,8 @@ import (
 
 var _ resource.Resource = &EndpointResource{}
 var _ resource.ResourceWithImportState = &EndpointResource{}
+var _ resource.ResourceWithIdentity = &EndpointResource{}
+var _ resource.ResourceWithModifyPlan = &EndpointResource{}
 
 func NewEndpointResource() resource.Resource {
 	return &EndpointResource{}
@@ -24,6 +31,7 @@ func NewEndpointResource() resource.Resource {
 
 type EndpointResource struct {
 	client *Client
+	config *providerConfig
 }
 
 type EndpointResourceModel struct {
@@ -34,8 +42,8 @@ type EndpointResourceModel struct {
 	GPUCount            types.Int64  `tfsdk:"gpu_count"`
 	VCPUCount           types.Int64  `tfsdk:"vcpu_count"`
 	GPUTypeIds          types.List   `tfsdk:"gpu_type_ids"`
-	CPUFlavorIds        types.List   `tfsdk:"cpu_flavor_ids"`
-	DataCenterIds       types.List   `tfsdk:"data_center_ids"`
+	CPUFlavorIds        types.Set    `tfsdk:"cpu_flavor_ids"`
+	DataCenterIds       types.Set    `tfsdk:"data_center_ids"`
 	NetworkVolumeId     types.String `tfsdk:"network_volume_id"`
 	WorkersMin          types.Int64  `tfsdk:"workers_min"`
 	WorkersMax          types.Int64  `tfsdk:"workers_max"`
@@ -43,12 +51,14 @@ type EndpointResourceModel struct {
 	ExecutionTimeoutMs  types.Int64  `tfsdk:"execution_timeout_ms"`
 	ScalerType          types.String `tfsdk:"scaler_type"`
 	ScalerValue         types.Int64  `tfsdk:"scaler_value"`
-	AllowedCudaVersions types.List   `tfsdk:"allowed_cuda_versions"`
+	AllowedCudaVersions types.Set    `tfsdk:"allowed_cuda_versions"`
 	Flashboot           types.Bool   `tfsdk:"flashboot"`
 	// Computed fields
 	CreatedAt types.String `tfsdk:"created_at"`
 	UserId    types.String `tfsdk:"user_id"`
 	Version   types.Int64  `tfsdk:"version"`
+
+	EstimatedCostPerHr types.Float64 `tfsdk:"estimated_cost_per_hr"`
 }
 
 func (r *EndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -70,9 +80,13 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 			"name": schema.StringAttribute{
 				MarkdownDescription: "A user-defined name for the Endpoint.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"template_id": schema.StringAttribute{
-				MarkdownDescription: "The unique identifier of the template used to create the Endpoint.",
+				MarkdownDescription: "The unique identifier of the template used to create the Endpoint. Endpoints have no environment of their own; set secrets with `secret_env` on the `runpod_template`.",
 				Required:            true,
 			},
 			"compute_type": schema.StringAttribute{
@@ -80,6 +94,9 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("GPU"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"gpu_count": schema.Int64Attribute{
 				MarkdownDescription: "The number of GPUs attached to each worker on the Endpoint.",
@@ -94,19 +111,27 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 				Default:             int64default.StaticInt64(2),
 			},
 			"gpu_type_ids": schema.ListAttribute{
-				MarkdownDescription: "A list of RunPod GPU types which can be attached to workers.",
+				MarkdownDescription: "A list of RunPod GPU types which can be attached to workers, in order of preference.",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"cpu_flavor_ids": schema.ListAttribute{
-				MarkdownDescription: "A list of RunPod CPU flavors which can be attached to workers.",
+			"cpu_flavor_ids": schema.SetAttribute{
+				MarkdownDescription: "A set of RunPod CPU flavors which can be attached to workers.",
 				ElementType:         types.StringType,
 				Optional:            true,
 			},
-			"data_center_ids": schema.ListAttribute{
-				MarkdownDescription: "A list of RunPod data center IDs where workers can be located.",
+			"data_center_ids": schema.SetAttribute{
+				MarkdownDescription: "A set of RunPod data center IDs where workers can be located.",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"network_volume_id": schema.StringAttribute{
 				MarkdownDescription: "The unique identifier of the network volume to attach to the Endpoint.",
@@ -121,6 +146,10 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 			"workers_max": schema.Int64Attribute{
 				MarkdownDescription: "The maximum number of workers that can be running at the same time.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"idle_timeout": schema.Int64Attribute{
 				MarkdownDescription: "The number of seconds a worker can run without taking a job before the worker is scaled down.",
@@ -131,6 +160,10 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 			"execution_timeout_ms": schema.Int64Attribute{
 				MarkdownDescription: "The maximum number of milliseconds a request can run before the worker is stopped.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"scaler_type": schema.StringAttribute{
 				MarkdownDescription: "The method used to scale up workers. QUEUE_DELAY or REQUEST_COUNT.",
@@ -144,13 +177,17 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				Default:             int64default.StaticInt64(4),
 			},
-			"allowed_cuda_versions": schema.ListAttribute{
-				MarkdownDescription: "A list of acceptable CUDA versions on the workers.",
+			"allowed_cuda_versions": schema.SetAttribute{
+				MarkdownDescription: "A set of acceptable CUDA versions on the workers.",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"flashboot": schema.BoolAttribute{
-				MarkdownDescription: "Whether to use flash boot for the Endpoint.",
+				MarkdownDescription: "Whether to use flash boot for the Endpoint. Leaving it unset is the same as `false`.",
 				Optional:            true,
 			},
 			"created_at": schema.StringAttribute{
@@ -165,25 +202,34 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "The version number of the Endpoint.",
 				Computed:            true,
 			},
+			"estimated_cost_per_hr": schema.Float64Attribute{
+				MarkdownDescription: "The estimated cost in USD per hour of the `workers_min` always-on workers of the Endpoint, computed at plan time from secure cloud GPU pricing and `gpu_count`. When several `gpu_type_ids` are listed the most expensive one is assumed. Workers started to handle requests are not included. Null for CPU Endpoints.",
+				Computed:            true,
+			},
 		},
 	}
 }
 
+func (r *EndpointResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema("Endpoint", resp)
+}
+
 func (r *EndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*Client)
+	config, ok := req.ProviderData.(*providerConfig)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = config.client
+	r.config = config
 }
 
 func (r *EndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -196,7 +242,7 @@ func (r *EndpointResource) Create(ctx context.Context, req resource.CreateReques
 	tflog.Debug(ctx, "Creating Endpoint")
 
 	input := &EndpointCreateInput{
-		Name:            data.Name.ValueString(),
+		Name:            r.config.prefixedName(data.Name.ValueString()),
 		TemplateId:      data.TemplateId.ValueString(),
 		ComputeType:     data.ComputeType.ValueString(),
 		NetworkVolumeId: data.NetworkVolumeId.ValueString(),
@@ -215,7 +261,7 @@ func (r *EndpointResource) Create(ctx context.Context, req resource.CreateReques
 		workersMin := int(data.WorkersMin.ValueInt64())
 		input.WorkersMin = &workersMin
 	}
-	if !data.WorkersMax.IsNull() {
+	if !data.WorkersMax.IsNull() && !data.WorkersMax.IsUnknown() {
 		workersMax := int(data.WorkersMax.ValueInt64())
 		input.WorkersMax = &workersMax
 	}
@@ -223,7 +269,7 @@ func (r *EndpointResource) Create(ctx context.Context, req resource.CreateReques
 		idleTimeout := int(data.IdleTimeout.ValueInt64())
 		input.IdleTimeout = &idleTimeout
 	}
-	if !data.ExecutionTimeoutMs.IsNull() {
+	if !data.ExecutionTimeoutMs.IsNull() && !data.ExecutionTimeoutMs.IsUnknown() {
 		execTimeout := int(data.ExecutionTimeoutMs.ValueInt64())
 		input.ExecutionTimeoutMs = &execTimeout
 	}
@@ -236,16 +282,16 @@ func (r *EndpointResource) Create(ctx context.Context, req resource.CreateReques
 		input.Flashboot = &flashboot
 	}
 
-	if !data.GPUTypeIds.IsNull() {
+	if !data.GPUTypeIds.IsNull() && !data.GPUTypeIds.IsUnknown() {
 		resp.Diagnostics.Append(data.GPUTypeIds.ElementsAs(ctx, &input.GPUTypeIds, false)...)
 	}
 	if !data.CPUFlavorIds.IsNull() {
 		resp.Diagnostics.Append(data.CPUFlavorIds.ElementsAs(ctx, &input.CPUFlavorIds, false)...)
 	}
-	if !data.DataCenterIds.IsNull() {
+	if !data.DataCenterIds.IsNull() && !data.DataCenterIds.IsUnknown() {
 		resp.Diagnostics.Append(data.DataCenterIds.ElementsAs(ctx, &input.DataCenterIds, false)...)
 	}
-	if !data.AllowedCudaVersions.IsNull() {
+	if !data.AllowedCudaVersions.IsNull() && !data.AllowedCudaVersions.IsUnknown() {
 		resp.Diagnostics.Append(data.AllowedCudaVersions.ElementsAs(ctx, &input.AllowedCudaVersions, false)...)
 	}
 
@@ -261,8 +307,11 @@ func (r *EndpointResource) Create(ctx context.Context, req resource.CreateReques
 
 	tflog.Trace(ctx, "Created Endpoint", map[string]interface{}{"id": endpoint.ID})
 
-	r.updateStateFromEndpoint(ctx, &data, endpoint)
+	resp.Diagnostics.Append(r.applyDefaultEnv(ctx, data.TemplateId.ValueString())...)
+
+	resp.Diagnostics.Append(r.updateStateFromEndpoint(ctx, &data, endpoint)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *EndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -280,8 +329,9 @@ func (r *EndpointResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	r.updateStateFromEndpoint(ctx, &data, endpoint)
+	resp.Diagnostics.Append(r.updateStateFromEndpoint(ctx, &data, endpoint)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -294,7 +344,7 @@ func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateReques
 	tflog.Debug(ctx, "Updating Endpoint", map[string]interface{}{"id": data.ID.ValueString()})
 
 	input := &EndpointUpdateInput{
-		Name:            data.Name.ValueString(),
+		Name:            r.config.prefixedName(data.Name.ValueString()),
 		TemplateId:      data.TemplateId.ValueString(),
 		NetworkVolumeId: data.NetworkVolumeId.ValueString(),
 		ScalerType:      data.ScalerType.ValueString(),
@@ -312,7 +362,7 @@ func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateReques
 		workersMin := int(data.WorkersMin.ValueInt64())
 		input.WorkersMin = &workersMin
 	}
-	if !data.WorkersMax.IsNull() {
+	if !data.WorkersMax.IsNull() && !data.WorkersMax.IsUnknown() {
 		workersMax := int(data.WorkersMax.ValueInt64())
 		input.WorkersMax = &workersMax
 	}
@@ -320,7 +370,7 @@ func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateReques
 		idleTimeout := int(data.IdleTimeout.ValueInt64())
 		input.IdleTimeout = &idleTimeout
 	}
-	if !data.ExecutionTimeoutMs.IsNull() {
+	if !data.ExecutionTimeoutMs.IsNull() && !data.ExecutionTimeoutMs.IsUnknown() {
 		execTimeout := int(data.ExecutionTimeoutMs.ValueInt64())
 		input.ExecutionTimeoutMs = &execTimeout
 	}
@@ -333,16 +383,16 @@ func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateReques
 		input.Flashboot = &flashboot
 	}
 
-	if !data.GPUTypeIds.IsNull() {
+	if !data.GPUTypeIds.IsNull() && !data.GPUTypeIds.IsUnknown() {
 		resp.Diagnostics.Append(data.GPUTypeIds.ElementsAs(ctx, &input.GPUTypeIds, false)...)
 	}
 	if !data.CPUFlavorIds.IsNull() {
 		resp.Diagnostics.Append(data.CPUFlavorIds.ElementsAs(ctx, &input.CPUFlavorIds, false)...)
 	}
-	if !data.DataCenterIds.IsNull() {
+	if !data.DataCenterIds.IsNull() && !data.DataCenterIds.IsUnknown() {
 		resp.Diagnostics.Append(data.DataCenterIds.ElementsAs(ctx, &input.DataCenterIds, false)...)
 	}
-	if !data.AllowedCudaVersions.IsNull() {
+	if !data.AllowedCudaVersions.IsNull() && !data.AllowedCudaVersions.IsUnknown() {
 		resp.Diagnostics.Append(data.AllowedCudaVersions.ElementsAs(ctx, &input.AllowedCudaVersions, false)...)
 	}
 
@@ -358,8 +408,11 @@ func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateReques
 
 	tflog.Trace(ctx, "Updated Endpoint", map[string]interface{}{"id": endpoint.ID})
 
-	r.updateStateFromEndpoint(ctx, &data, endpoint)
+	resp.Diagnostics.Append(r.applyDefaultEnv(ctx, data.TemplateId.ValueString())...)
+
+	resp.Diagnostics.Append(r.updateStateFromEndpoint(ctx, &data, endpoint)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *EndpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -380,44 +433,219 @@ func (r *EndpointResource) Delete(ctx context.Context, req resource.DeleteReques
 	tflog.Trace(ctx, "Deleted Endpoint", map[string]interface{}{"id": data.ID.ValueString()})
 }
 
+// applyDefaultEnv writes the provider's default_env to the environment of
+// the Endpoint's template. Endpoints have no environment of their own.
+// Variables the template sets itself take precedence.
+func (r *EndpointResource) applyDefaultEnv(ctx context.Context, templateId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(r.config.defaultEnv) == 0 {
+		return diags
+	}
+
+	template, err := r.client.GetTemplate(ctx, templateId)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read endpoint template, got error: %s", err))
+		return diags
+	}
+
+	env := r.config.mergedEnv(template.Env)
+	if maps.Equal(env, template.Env) {
+		return diags
+	}
+
+	if _, err := r.client.UpdateTemplate(ctx, templateId, &TemplateUpdateInput{Env: env}); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to set environment on endpoint template, got error: %s", err))
+	}
+	return diags
+}
+
+// ModifyPlan computes estimated_cost_per_hr from the cached GPU pricing
+// catalog, so that plans show what a change will cost.
+func (r *EndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to estimate when destroying, or before the provider is
+	// configured.
+	if req.Plan.Raw.IsNull() || r.config == nil {
+		return
+	}
+
+	var plan EndpointResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	catalog, err := r.config.gpuCatalog(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Estimate Cost",
+			fmt.Sprintf("Unable to read GPU pricing, got error: %s. estimated_cost_per_hr will be known after apply.", err),
+		)
+		return
+	}
+
+	estimate, diags := estimateEndpointCostPerHr(ctx, catalog, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	// Keep the recorded estimate while the inputs it depends on are
+	// unchanged, so that price changes alone never produce a diff.
+	if !req.State.Raw.IsNull() {
+		var state EndpointResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		current, diags := estimateEndpointCostPerHr(ctx, catalog, &state)
+		resp.Diagnostics.Append(diags...)
+
+		if current.Equal(estimate) && !state.EstimatedCostPerHr.IsNull() {
+			estimate = state.EstimatedCostPerHr
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("estimated_cost_per_hr"), estimate)...)
+}
+
+// refreshEstimatedCost fills in estimated_cost_per_hr when planning could not
+// compute it, for example after import. A recorded estimate is kept.
+func (r *EndpointResource) refreshEstimatedCost(ctx context.Context, data *EndpointResourceModel) diag.Diagnostics {
+	if !data.EstimatedCostPerHr.IsNull() && !data.EstimatedCostPerHr.IsUnknown() {
+		return nil
+	}
+
+	data.EstimatedCostPerHr = types.Float64Null()
+
+	catalog, err := r.config.gpuCatalog(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to read GPU pricing", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+
+	estimate, diags := estimateEndpointCostPerHr(ctx, catalog, data)
+	if !estimate.IsUnknown() {
+		data.EstimatedCostPerHr = estimate
+	}
+	return diags
+}
+
+// ImportState imports an Endpoint by ID or identity, or by name given as
+// name:<name>.
 func (r *EndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	name, ok := importName(req.ID)
+	if !ok {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		return
+	}
+
+	endpoints, err := r.client.ListEndpoints(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list endpoints, got error: %s", err))
+		return
+	}
+
+	candidates := make([]importCandidate, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		candidates = append(candidates, importCandidate{id: endpoint.ID, name: endpoint.Name})
+	}
+
+	id, diags := resolveImportName("Endpoint", r.config.prefixedName(name), candidates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
-func (r *EndpointResource) updateStateFromEndpoint(ctx context.Context, data *EndpointResourceModel, endpoint *Endpoint) {
+// updateStateFromEndpoint updates the Terraform state from an Endpoint API
+// response. Numeric fields are decoded as pointers, so a value changed to zero
+// outside of Terraform is told apart from one the API did not report.
+// vcpu_count and cpu_flavor_ids are not part of the Endpoint schema and keep
+// their prior value, or their schema default after import.
+func (r *EndpointResource) updateStateFromEndpoint(ctx context.Context, data *EndpointResourceModel, endpoint *Endpoint) diag.Diagnostics {
+	var diags diag.Diagnostics
+
 	data.ID = types.StringValue(endpoint.ID)
-	data.Name = types.StringValue(endpoint.Name)
-	data.TemplateId = types.StringValue(endpoint.TemplateId)
-	data.ComputeType = types.StringValue(endpoint.ComputeType)
+	data.TemplateId = refreshString(data.TemplateId, endpoint.TemplateId)
+	data.ComputeType = refreshString(data.ComputeType, endpoint.ComputeType)
+	data.ScalerType = refreshString(data.ScalerType, endpoint.ScalerType)
 	data.CreatedAt = types.StringValue(endpoint.CreatedAt)
 	data.UserId = types.StringValue(endpoint.UserId)
-	data.Version = types.Int64Value(int64(endpoint.Version))
+	data.Version = int64PointerValue(endpoint.Version)
+
+	// An empty name or network volume means none is set.
+	if endpoint.Name != "" {
+		data.Name = types.StringValue(r.config.unprefixedName(endpoint.Name))
+	} else {
+		data.Name = types.StringNull()
+	}
+	if endpoint.NetworkVolumeId != "" {
+		data.NetworkVolumeId = types.StringValue(endpoint.NetworkVolumeId)
+	} else {
+		data.NetworkVolumeId = types.StringNull()
+	}
 
-	if endpoint.GPUCount > 0 {
-		data.GPUCount = types.Int64Value(int64(endpoint.GPUCount))
+	data.WorkersMin = refreshInt64(data.WorkersMin, endpoint.WorkersMin)
+	data.WorkersMax = refreshInt64(data.WorkersMax, endpoint.WorkersMax)
+	data.IdleTimeout = refreshInt64(data.IdleTimeout, endpoint.IdleTimeout)
+	data.ExecutionTimeoutMs = refreshInt64(data.ExecutionTimeoutMs, endpoint.ExecutionTimeoutMs)
+	data.ScalerValue = refreshInt64(data.ScalerValue, endpoint.ScalerValue)
+
+	// Flash boot is off unless enabled, so a disabled flash boot that was
+	// never configured stays null.
+	if endpoint.Flashboot != nil && (*endpoint.Flashboot || !data.Flashboot.IsNull()) {
+		data.Flashboot = types.BoolValue(*endpoint.Flashboot)
 	}
-	if endpoint.VCPUCount > 0 {
-		data.VCPUCount = types.Int64Value(int64(endpoint.VCPUCount))
+
+	// CPU endpoints report no GPUs, which must not override the gpu_count
+	// default.
+	if data.ComputeType.ValueString() != "CPU" {
+		data.GPUCount = refreshInt64(data.GPUCount, endpoint.GPUCount)
 	}
-	if endpoint.WorkersMin >= 0 {
-		data.WorkersMin = types.Int64Value(int64(endpoint.WorkersMin))
+
+	gpuTypeIds, d := refreshUnorderedList(ctx, data.GPUTypeIds, endpoint.GPUTypeIds)
+	diags.Append(d...)
+	data.GPUTypeIds = gpuTypeIds
+
+	dataCenterIds, d := stringSetValue(ctx, endpoint.DataCenterIds)
+	diags.Append(d...)
+	data.DataCenterIds = dataCenterIds
+
+	allowedCudaVersions, d := stringSetValue(ctx, endpoint.AllowedCudaVersions)
+	diags.Append(d...)
+	data.AllowedCudaVersions = allowedCudaVersions
+
+	setEndpointDefaults(data)
+
+	diags.Append(r.refreshEstimatedCost(ctx, data)...)
+
+	return diags
+}
+
+// setEndpointDefaults fills attributes with schema defaults when they have no
+// value, so that an imported Endpoint plans cleanly.
+func setEndpointDefaults(data *EndpointResourceModel) {
+	if data.ComputeType.IsNull() {
+		data.ComputeType = types.StringValue("GPU")
 	}
-	if endpoint.WorkersMax > 0 {
-		data.WorkersMax = types.Int64Value(int64(endpoint.WorkersMax))
+	if data.GPUCount.IsNull() {
+		data.GPUCount = types.Int64Value(1)
 	}
-	if endpoint.IdleTimeout > 0 {
-		data.IdleTimeout = types.Int64Value(int64(endpoint.IdleTimeout))
+	if data.VCPUCount.IsNull() {
+		data.VCPUCount = types.Int64Value(2)
 	}
-	if endpoint.ExecutionTimeoutMs > 0 {
-		data.ExecutionTimeoutMs = types.Int64Value(int64(endpoint.ExecutionTimeoutMs))
+	if data.WorkersMin.IsNull() {
+		data.WorkersMin = types.Int64Value(0)
 	}
-	if endpoint.ScalerValue > 0 {
-		data.ScalerValue = types.Int64Value(int64(endpoint.ScalerValue))
+	if data.IdleTimeout.IsNull() {
+		data.IdleTimeout = types.Int64Value(5)
 	}
-	if endpoint.ScalerType != "" {
-		data.ScalerType = types.StringValue(endpoint.ScalerType)
+	if data.ScalerType.IsNull() {
+		data.ScalerType = types.StringValue("QUEUE_DELAY")
 	}
-	if endpoint.NetworkVolumeId != "" {
-		data.NetworkVolumeId = types.StringValue(endpoint.NetworkVolumeId)
+	if data.ScalerValue.IsNull() {
+		data.ScalerValue = types.Int64Value(4)
 	}
 }
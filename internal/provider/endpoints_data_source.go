@@ -150,21 +150,22 @@ func (d *EndpointsDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 
 	for _, endpoint := range endpoints {
+		// The Endpoint schema does not report the vCPU count of CPU workers.
 		endpointData := EndpointDataModel{
 			ID:                 types.StringValue(endpoint.ID),
 			Name:               types.StringValue(endpoint.Name),
 			TemplateId:         types.StringValue(endpoint.TemplateId),
 			ComputeType:        types.StringValue(endpoint.ComputeType),
-			GPUCount:           types.Int64Value(int64(endpoint.GPUCount)),
-			VCPUCount:          types.Int64Value(int64(endpoint.VCPUCount)),
-			WorkersMin:         types.Int64Value(int64(endpoint.WorkersMin)),
-			WorkersMax:         types.Int64Value(int64(endpoint.WorkersMax)),
-			IdleTimeout:        types.Int64Value(int64(endpoint.IdleTimeout)),
-			ExecutionTimeoutMs: types.Int64Value(int64(endpoint.ExecutionTimeoutMs)),
+			GPUCount:           int64PointerValue(endpoint.GPUCount),
+			VCPUCount:          types.Int64Null(),
+			WorkersMin:         int64PointerValue(endpoint.WorkersMin),
+			WorkersMax:         int64PointerValue(endpoint.WorkersMax),
+			IdleTimeout:        int64PointerValue(endpoint.IdleTimeout),
+			ExecutionTimeoutMs: int64PointerValue(endpoint.ExecutionTimeoutMs),
 			ScalerType:         types.StringValue(endpoint.ScalerType),
-			ScalerValue:        types.Int64Value(int64(endpoint.ScalerValue)),
+			ScalerValue:        int64PointerValue(endpoint.ScalerValue),
 			CreatedAt:          types.StringValue(endpoint.CreatedAt),
-			Version:            types.Int64Value(int64(endpoint.Version)),
+			Version:            int64PointerValue(endpoint.Version),
 		}
 		data.Endpoints = append(data.Endpoints, endpointData)
 	}
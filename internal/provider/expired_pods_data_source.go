@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &ExpiredPodsDataSource{}
+
+func NewExpiredPodsDataSource() datasource.DataSource {
+	return &ExpiredPodsDataSource{}
+}
+
+type ExpiredPodsDataSource struct {
+	client *Client
+}
+
+type ExpiredPodsDataSourceModel struct {
+	Pods []ExpiredPodDataModel `tfsdk:"pods"`
+}
+
+type ExpiredPodDataModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	DesiredStatus types.String `tfsdk:"desired_status"`
+	ExpiresAt     types.String `tfsdk:"expires_at"`
+}
+
+func (d *ExpiredPodsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_expired_pods"
+}
+
+func (d *ExpiredPodsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to list the RunPod Pods whose `ttl` has passed, oldest expiry first. " +
+			"Pods are found by the expiry the provider records on them, so the list includes Pods managed by any Terraform configuration.",
+
+		Attributes: map[string]schema.Attribute{
+			"pods": schema.ListNestedAttribute{
+				MarkdownDescription: "List of expired Pods.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the Pod.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the Pod.",
+							Computed:            true,
+						},
+						"desired_status": schema.StringAttribute{
+							MarkdownDescription: "The current expected status of the Pod.",
+							Computed:            true,
+						},
+						"expires_at": schema.StringAttribute{
+							MarkdownDescription: "The UTC timestamp at which the Pod expired.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ExpiredPodsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ExpiredPodsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ExpiredPodsDataSourceModel
+
+	// Initialize empty slice
+	data.Pods = []ExpiredPodDataModel{}
+
+	tflog.Debug(ctx, "Reading Expired Pods data source")
+
+	pods, err := d.client.ListPods(ctx, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list pods, got error: %s", err))
+		return
+	}
+
+	now := time.Now()
+	var expired []Pod
+	for _, pod := range pods {
+		if expiry, ok := podExpiry(&pod); ok && now.After(expiry) {
+			expired = append(expired, pod)
+		}
+	}
+
+	sort.SliceStable(expired, func(i, j int) bool {
+		a, _ := podExpiry(&expired[i])
+		b, _ := podExpiry(&expired[j])
+		return a.Before(b)
+	})
+
+	for _, pod := range expired {
+		expiry, _ := podExpiry(&pod)
+		data.Pods = append(data.Pods, ExpiredPodDataModel{
+			ID:            types.StringValue(pod.ID),
+			Name:          types.StringValue(pod.Name),
+			DesiredStatus: types.StringValue(pod.DesiredStatus),
+			ExpiresAt:     types.StringValue(expiry.UTC().Format(time.RFC3339)),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
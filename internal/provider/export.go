@@ -0,0 +1,467 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	// OutDir is the directory the configuration is written to. It is created
+	// if it does not exist.
+	OutDir string
+	// Profile is the runpodctl profile to read the API key from. When empty,
+	// the API key is read from RUNPOD_API_KEY or the default profile, as the
+	// provider does.
+	Profile string
+}
+
+// exportedResource is a resource written by Export.
+type exportedResource struct {
+	typeName string
+	label    string
+	id       string
+	schema   schema.Schema
+	state    tfsdk.State
+}
+
+// address returns the address of the resource in the generated
+// configuration.
+func (e *exportedResource) address() string {
+	return e.typeName + "." + e.label
+}
+
+// exportFiles are the files written by Export, in the order their resources
+// are generated, so that referenced resources are labelled first.
+var exportFiles = []struct {
+	typeName string
+	file     string
+}{
+	{"runpod_network_volume", "network_volumes.tf"},
+	{"runpod_template", "templates.tf"},
+	{"runpod_pod", "pods.tf"},
+	{"runpod_endpoint", "endpoints.tf"},
+}
+
+// Export writes the Network Volumes, Templates, Pods and Endpoints of the
+// account as Terraform configuration to opts.OutDir, with one file per
+// resource type and an import block for every resource in imports.tf. IDs of
+// other exported resources are written as references to them. It returns the
+// number of resources exported.
+//
+// The resources are built from the list endpoints in the same way as when
+// they are imported, so that a plan after importing shows no changes.
+func Export(ctx context.Context, opts ExportOptions) (int, error) {
+	config := runpodProviderModel{
+		ApiKey:        types.StringNull(),
+		ApiKeyFile:    types.StringNull(),
+		ApiKeyCommand: types.ListNull(types.StringType),
+		Profile:       types.StringNull(),
+	}
+	if opts.Profile != "" {
+		config.Profile = types.StringValue(opts.Profile)
+	}
+
+	apiKey, _, err := resolveAPIKey(ctx, config)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read RunPod API key: %w", err)
+	}
+	if apiKey == "" {
+		return 0, errors.New("missing RunPod API key: set RUNPOD_API_KEY or use -profile")
+	}
+
+	client := NewClient(apiKey)
+	if err := client.ValidateAPIKey(ctx); err != nil {
+		return 0, fmt.Errorf("the RunPod API rejected the API key: %w", err)
+	}
+
+	resources, err := exportResources(ctx, &providerConfig{client: client})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := writeExport(resources, opts.OutDir); err != nil {
+		return 0, err
+	}
+	return len(resources), nil
+}
+
+// exportResources lists the resources of the account and builds their state.
+func exportResources(ctx context.Context, config *providerConfig) ([]*exportedResource, error) {
+	var resources []*exportedResource
+	labels := map[string]bool{}
+
+	add := func(r resource.Resource, typeName string, id string, data interface{}, label string) error {
+		state, diags := exportState(ctx, r, data)
+		if diags.HasError() {
+			return fmt.Errorf("unable to export %s %s: %s", typeName, id, diagsError(diags))
+		}
+
+		resources = append(resources, &exportedResource{
+			typeName: typeName,
+			label:    uniqueLabel(labels, typeName, label),
+			id:       id,
+			schema:   resourceSchema(ctx, r),
+			state:    state,
+		})
+		return nil
+	}
+
+	volumes, err := config.client.ListNetworkVolumes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list network volumes: %w", err)
+	}
+	volumeResource := &NetworkVolumeResource{client: config.client}
+	for _, volume := range volumes {
+		var data NetworkVolumeResourceModel
+		if diags := importedModel(ctx, volumeResource, &data, func() diag.Diagnostics {
+			updateStateFromNetworkVolume(&data, &volume)
+			return nil
+		}); diags.HasError() {
+			return nil, fmt.Errorf("unable to export network volume %s: %s", volume.ID, diagsError(diags))
+		}
+		if err := add(volumeResource, "runpod_network_volume", volume.ID, &data, volume.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Templates used by Endpoints are included, so that the Endpoints
+	// reference them.
+	templates, err := config.client.ListTemplates(ctx, &ListTemplatesFilter{IncludeEndpointBound: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list templates: %w", err)
+	}
+	templateResource := &TemplateResource{client: config.client, config: config}
+	for _, template := range templates {
+		// Templates provided by RunPod are not owned by the account.
+		if template.IsRunpod {
+			continue
+		}
+
+		var data TemplateResourceModel
+		if diags := importedModel(ctx, templateResource, &data, func() diag.Diagnostics {
+			return templateResource.updateStateFromTemplate(ctx, &data, &template, nil)
+		}); diags.HasError() {
+			return nil, fmt.Errorf("unable to export template %s: %s", template.ID, diagsError(diags))
+		}
+		if err := add(templateResource, "runpod_template", template.ID, &data, template.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	// The machine and network volume are needed to build the same state as
+	// an import, which reads the Pod with GetPod.
+	pods, err := config.client.ListPods(ctx, &ListPodsFilter{IncludeDetails: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pods: %w", err)
+	}
+	podResource := &PodResource{client: config.client, config: config}
+	for _, pod := range pods {
+		var data PodResourceModel
+		if diags := importedModel(ctx, podResource, &data, func() diag.Diagnostics {
+			diags := podResource.updateStateFromPod(ctx, &data, &pod, nil)
+			return append(diags, adoptPodPlacement(ctx, &data, &pod)...)
+		}); diags.HasError() {
+			return nil, fmt.Errorf("unable to export pod %s: %s", pod.ID, diagsError(diags))
+		}
+		if err := add(podResource, "runpod_pod", pod.ID, &data, pod.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	endpoints, err := config.client.ListEndpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list endpoints: %w", err)
+	}
+	endpointResource := &EndpointResource{client: config.client, config: config}
+	for _, endpoint := range endpoints {
+		var data EndpointResourceModel
+		if diags := importedModel(ctx, endpointResource, &data, func() diag.Diagnostics {
+			return endpointResource.updateStateFromEndpoint(ctx, &data, &endpoint)
+		}); diags.HasError() {
+			return nil, fmt.Errorf("unable to export endpoint %s: %s", endpoint.ID, diagsError(diags))
+		}
+		if err := add(endpointResource, "runpod_endpoint", endpoint.ID, &data, endpoint.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+// importedModel fills data as the state of a freshly imported resource, with
+// every attribute null, and then refreshes it from the API object.
+func importedModel(ctx context.Context, r resource.Resource, data interface{}, refresh func() diag.Diagnostics) diag.Diagnostics {
+	state := nullState(ctx, resourceSchema(ctx, r))
+
+	diags := state.Get(ctx, data)
+	if !diags.HasError() {
+		diags.Append(refresh()...)
+	}
+	return diags
+}
+
+// exportState converts a resource model into state.
+func exportState(ctx context.Context, r resource.Resource, data interface{}) (tfsdk.State, diag.Diagnostics) {
+	state := nullState(ctx, resourceSchema(ctx, r))
+	diags := state.Set(ctx, data)
+	return state, diags
+}
+
+// resourceSchema returns the schema of a resource.
+func resourceSchema(ctx context.Context, r resource.Resource) schema.Schema {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+// nullState returns state for the schema with every attribute null.
+func nullState(ctx context.Context, s schema.Schema) tfsdk.State {
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, t := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(t, nil)
+	}
+
+	return tfsdk.State{Schema: s, Raw: tftypes.NewValue(objectType, values)}
+}
+
+// diagsError joins the errors in diags into one message.
+func diagsError(diags diag.Diagnostics) string {
+	var messages []string
+	for _, d := range diags.Errors() {
+		messages = append(messages, fmt.Sprintf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return strings.Join(messages, "; ")
+}
+
+var labelInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// uniqueLabel returns a resource label derived from name that is not yet
+// used for the resource type.
+func uniqueLabel(used map[string]bool, typeName string, name string) string {
+	label := strings.Trim(labelInvalidChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = strings.TrimPrefix(typeName, "runpod_") + "_" + label
+		label = strings.TrimSuffix(label, "_")
+	}
+
+	unique := label
+	for i := 2; used[typeName+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	used[typeName+"."+unique] = true
+	return unique
+}
+
+// writeExport writes the configuration of resources to dir.
+func writeExport(resources []*exportedResource, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("unable to create %s: %w", dir, err)
+	}
+
+	// References are only written for IDs of resources in the export.
+	addresses := make(map[string]string, len(resources))
+	for _, r := range resources {
+		addresses[r.id] = r.address()
+	}
+
+	const header = "# Generated by terraform-provider-runpod export.\n"
+
+	for _, f := range exportFiles {
+		var b strings.Builder
+		b.WriteString(header)
+		for _, r := range resources {
+			if r.typeName != f.typeName {
+				continue
+			}
+			b.WriteString("\n")
+			if err := writeResourceBlock(&b, r, addresses); err != nil {
+				return err
+			}
+		}
+		if err := os.WriteFile(filepath.Join(dir, f.file), []byte(b.String()), 0o644); err != nil {
+			return fmt.Errorf("unable to write %s: %w", f.file, err)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	for _, r := range resources {
+		fmt.Fprintf(&b, "\nimport {\n  to = %s\n  id = %s\n}\n", r.address(), hclString(r.id))
+	}
+	if err := os.WriteFile(filepath.Join(dir, "imports.tf"), []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("unable to write imports.tf: %w", err)
+	}
+
+	return nil
+}
+
+// writeResourceBlock writes the resource block of r. Only attributes that can
+// be configured and have a value are written; sensitive attributes are left
+// out, as the API never returns them.
+func writeResourceBlock(b *strings.Builder, r *exportedResource, addresses map[string]string) error {
+	var values map[string]tftypes.Value
+	if err := r.state.Raw.As(&values); err != nil {
+		return fmt.Errorf("unable to export %s: %w", r.address(), err)
+	}
+
+	var names []string
+	width := 0
+	for name, attribute := range r.schema.Attributes {
+		if name == "id" || attribute.IsSensitive() || !(attribute.IsRequired() || attribute.IsOptional()) {
+			continue
+		}
+		if values[name].IsNull() {
+			continue
+		}
+		names = append(names, name)
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(b, "resource %q %q {\n", r.typeName, r.label)
+	for _, name := range names {
+		value, err := hclValue(values[name], "  ")
+		if err != nil {
+			return fmt.Errorf("unable to export %s.%s: %w", r.address(), name, err)
+		}
+
+		// IDs of other exported resources, such as template_id and
+		// network_volume_id, become references.
+		if strings.HasSuffix(name, "_id") && values[name].Type().Is(tftypes.String) {
+			var id string
+			if err := values[name].As(&id); err == nil {
+				if address, ok := addresses[id]; ok {
+					value = address + ".id"
+				}
+			}
+		}
+
+		fmt.Fprintf(b, "  %-*s = %s\n", width, name, value)
+	}
+	b.WriteString("}\n")
+
+	return nil
+}
+
+// hclValue renders a value as an HCL expression. Maps are written over
+// several lines indented below indent.
+func hclValue(v tftypes.Value, indent string) (string, error) {
+	switch t := v.Type(); {
+	case t.Is(tftypes.String):
+		var s string
+		if err := v.As(&s); err != nil {
+			return "", err
+		}
+		return hclString(s), nil
+	case t.Is(tftypes.Number):
+		var n big.Float
+		if err := v.As(&n); err != nil {
+			return "", err
+		}
+		return n.Text('f', -1), nil
+	case t.Is(tftypes.Bool):
+		var b bool
+		if err := v.As(&b); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%t", b), nil
+	case t.Is(tftypes.List{}), t.Is(tftypes.Set{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return "", err
+		}
+		rendered := make([]string, 0, len(elems))
+		for _, elem := range elems {
+			s, err := hclValue(elem, indent)
+			if err != nil {
+				return "", err
+			}
+			rendered = append(rendered, s)
+		}
+		return "[" + strings.Join(rendered, ", ") + "]", nil
+	case t.Is(tftypes.Map{}):
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return "", err
+		}
+		if len(elems) == 0 {
+			return "{}", nil
+		}
+		keys := make([]string, 0, len(elems))
+		width := 0
+		for k := range elems {
+			keys = append(keys, k)
+			if len(hclString(k)) > width {
+				width = len(hclString(k))
+			}
+		}
+		sort.Strings(keys)
+
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, k := range keys {
+			s, err := hclValue(elems[k], indent+"  ")
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&b, "%s  %-*s = %s\n", indent, width, hclString(k), s)
+		}
+		b.WriteString(indent + "}")
+		return b.String(), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// hclString renders s as a quoted HCL string, escaping template sequences so
+// that values such as {{ RUNPOD_SECRET_name }} or ${VAR} are kept literally.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '$', '%':
+			b.WriteRune(r)
+			if i+1 < len(s) && s[i+1] == '{' {
+				b.WriteRune(r)
+			}
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestHclString(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "hello", `"hello"`},
+		{"empty", "", `""`},
+		{"interpolation", "${HOME}/data", `"$${HOME}/data"`},
+		{"directive", "%{ if x }", `"%%{ if x }"`},
+		{"dollar without brace", "$HOME costs 5%", `"$HOME costs 5%"`},
+		{"trailing dollar", "price$", `"price$"`},
+		{"secret reference", "{{ RUNPOD_SECRET_hf_token }}", `"{{ RUNPOD_SECRET_hf_token }}"`},
+		{"quotes and backslashes", `say "hi" \o/`, `"say \"hi\" \\o/"`},
+		{"whitespace", "a\nb\tc\r", `"a\nb\tc\r"`},
+		{"control character", "a\x01b", `"a\u0001b"`},
+		{"unicode", "café", `"café"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hclString(tt.in); got != tt.want {
+				t.Errorf("hclString(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqueLabel(t *testing.T) {
+	used := map[string]bool{}
+
+	tests := []struct {
+		name     string
+		typeName string
+		in       string
+		want     string
+	}{
+		{"plain", "runpod_pod", "trainer", "trainer"},
+		{"duplicate", "runpod_pod", "trainer", "trainer_2"},
+		{"second duplicate", "runpod_pod", "Trainer", "trainer_3"},
+		{"other type", "runpod_template", "trainer", "trainer"},
+		{"invalid characters", "runpod_pod", "My Pod (v2)!", "my_pod_v2"},
+		{"leading digit", "runpod_pod", "4090-box", "pod_4090_box"},
+		{"leading digit duplicate", "runpod_pod", "4090 box", "pod_4090_box_2"},
+		{"empty", "runpod_network_volume", "", "network_volume"},
+		{"only invalid characters", "runpod_network_volume", "---", "network_volume_2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uniqueLabel(used, tt.typeName, tt.in); got != tt.want {
+				t.Errorf("uniqueLabel(%q, %q) = %q, want %q", tt.typeName, tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteResourceBlock(t *testing.T) {
+	ctx := context.Background()
+
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                schema.StringAttribute{Computed: true},
+			"name":              schema.StringAttribute{Required: true},
+			"template_id":       schema.StringAttribute{Optional: true},
+			"network_volume_id": schema.StringAttribute{Optional: true},
+			"api_key":           schema.StringAttribute{Optional: true, Sensitive: true},
+			"cost_per_hr":       schema.Float64Attribute{Computed: true},
+			"env":               schema.MapAttribute{Optional: true, ElementType: types.StringType},
+		},
+	}
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+
+	tests := []struct {
+		name            string
+		templateId      string
+		networkVolumeId string
+		want            string
+	}{
+		{
+			name:            "exported ids",
+			templateId:      "tpl1",
+			networkVolumeId: "vol1",
+			want: `resource "runpod_pod" "trainer" {
+  env               = {
+    "DATA" = "$${HOME}/data"
+  }
+  name              = "trainer"
+  network_volume_id = runpod_network_volume.models.id
+  template_id       = runpod_template.base.id
+}
+`,
+		},
+		{
+			name:            "ids outside the export",
+			templateId:      "runpod-torch",
+			networkVolumeId: "vol2",
+			want: `resource "runpod_pod" "trainer" {
+  env               = {
+    "DATA" = "$${HOME}/data"
+  }
+  name              = "trainer"
+  network_volume_id = "vol2"
+  template_id       = "runpod-torch"
+}
+`,
+		},
+	}
+
+	addresses := map[string]string{
+		"tpl1": "runpod_template.base",
+		"vol1": "runpod_network_volume.models",
+		"pod1": "runpod_pod.trainer",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &exportedResource{
+				typeName: "runpod_pod",
+				label:    "trainer",
+				id:       "pod1",
+				schema:   s,
+				state: tfsdk.State{
+					Schema: s,
+					Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+						"id":                tftypes.NewValue(tftypes.String, "pod1"),
+						"name":              tftypes.NewValue(tftypes.String, "trainer"),
+						"template_id":       tftypes.NewValue(tftypes.String, tt.templateId),
+						"network_volume_id": tftypes.NewValue(tftypes.String, tt.networkVolumeId),
+						"api_key":           tftypes.NewValue(tftypes.String, "secret"),
+						"cost_per_hr":       tftypes.NewValue(tftypes.Number, 0.5),
+						"env": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+							"DATA": tftypes.NewValue(tftypes.String, "${HOME}/data"),
+						}),
+					}),
+				},
+			}
+
+			var b strings.Builder
+			if err := writeResourceBlock(&b, r, addresses); err != nil {
+				t.Fatalf("writeResourceBlock() error = %s", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("writeResourceBlock() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// importNamePrefix marks an import identifier naming the object instead of
+// giving its ID, e.g. name:my-pod.
+const importNamePrefix = "name:"
+
+// importCandidate is an object an import identifier may resolve to.
+type importCandidate struct {
+	id   string
+	name string
+}
+
+// importName returns the name in an import identifier of the form
+// name:<name>, and whether the identifier has that form.
+func importName(id string) (string, bool) {
+	if !strings.HasPrefix(id, importNamePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(id, importNamePrefix), true
+}
+
+// resolveImportName returns the ID of the only candidate with the given
+// name. No match, or more than one, is an error, as Terraform must not adopt
+// the wrong object.
+func resolveImportName(kind string, name string, candidates []importCandidate) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var ids []string
+	for _, candidate := range candidates {
+		if candidate.name == name {
+			ids = append(ids, candidate.id)
+		}
+	}
+	sort.Strings(ids)
+
+	switch len(ids) {
+	case 1:
+		return ids[0], diags
+	case 0:
+		diags.AddError(
+			"Import Error",
+			fmt.Sprintf("No %s named %q exists.", kind, name),
+		)
+	default:
+		diags.AddError(
+			"Ambiguous Import Identifier",
+			fmt.Sprintf("More than one %s is named %q: %s. Import one of them by ID instead.", kind, name, strings.Join(ids, ", ")),
+		)
+	}
+	return "", diags
+}
@@ -0,0 +1,78 @@
+package provider
+
+import "testing"
+
+func TestImportName(t *testing.T) {
+	tests := []struct {
+		id       string
+		wantName string
+		wantOk   bool
+	}{
+		{"name:trainer", "trainer", true},
+		{"name:", "", true},
+		{"name:a:b", "a:b", true},
+		{"abc123", "", false},
+		{"Name:trainer", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			name, ok := importName(tt.id)
+			if name != tt.wantName || ok != tt.wantOk {
+				t.Errorf("importName(%q) = %q, %t, want %q, %t", tt.id, name, ok, tt.wantName, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestResolveImportName(t *testing.T) {
+	candidates := []importCandidate{
+		{id: "pod1", name: "trainer"},
+		{id: "pod3", name: "worker"},
+		{id: "pod2", name: "worker"},
+		{id: "pod4", name: "Trainer"},
+	}
+
+	tests := []struct {
+		name        string
+		importName  string
+		candidates  []importCandidate
+		wantId      string
+		wantSummary string
+	}{
+		{"unique", "trainer", candidates, "pod1", ""},
+		{"case sensitive", "Trainer", candidates, "pod4", ""},
+		{"missing", "eval", candidates, "", "Import Error"},
+		{"no candidates", "trainer", nil, "", "Import Error"},
+		{"ambiguous", "worker", candidates, "", "Ambiguous Import Identifier"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, diags := resolveImportName("Pod", tt.importName, tt.candidates)
+			if id != tt.wantId {
+				t.Errorf("resolveImportName(%q) = %q, want %q", tt.importName, id, tt.wantId)
+			}
+
+			summary := ""
+			if diags.HasError() {
+				summary = diags.Errors()[0].Summary()
+			}
+			if summary != tt.wantSummary {
+				t.Errorf("resolveImportName(%q) error = %q, want %q", tt.importName, summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestResolveImportNameListsAmbiguousIds(t *testing.T) {
+	_, diags := resolveImportName("Pod", "worker", []importCandidate{
+		{id: "pod3", name: "worker"},
+		{id: "pod2", name: "worker"},
+	})
+
+	want := `More than one Pod is named "worker": pod2, pod3. Import one of them by ID instead.`
+	if !diags.HasError() || diags.Errors()[0].Detail() != want {
+		t.Errorf("resolveImportName() = %v, want the error %q", diags, want)
+	}
+}
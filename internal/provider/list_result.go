@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// listResult builds the list result for an API object: its identity and,
+// when Terraform asks for it, the state the resource has after importing the
+// object. refresh fills data from the object.
+func listResult(ctx context.Context, req list.ListRequest, r resource.Resource, id string, displayName string, data interface{}, refresh func() diag.Diagnostics) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = displayName
+
+	result.Diagnostics.Append(setIDIdentity(ctx, result.Identity, id)...)
+	if !req.IncludeResource {
+		return result
+	}
+
+	result.Diagnostics.Append(importedModel(ctx, r, data, refresh)...)
+	if result.Diagnostics.HasError() {
+		return result
+	}
+	result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+
+	return result
+}
+
+// limitReached reports whether count results satisfy the limit of the list
+// request. A limit of zero means no limit.
+func limitReached(req list.ListRequest, count int) bool {
+	return req.Limit > 0 && int64(count) >= req.Limit
+}
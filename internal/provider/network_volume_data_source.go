@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &NetworkVolumeDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &NetworkVolumeDataSource{}
+
+func NewNetworkVolumeDataSource() datasource.DataSource {
+	return &NetworkVolumeDataSource{}
+}
+
+type NetworkVolumeDataSource struct {
+	client *Client
+}
+
+type NetworkVolumeDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	DataCenterId types.String `tfsdk:"data_center_id"`
+	Size         types.Int64  `tfsdk:"size"`
+	// Computed fields
+	AttachedPodIds       types.Set     `tfsdk:"attached_pod_ids"`
+	AttachedEndpointIds  types.Set     `tfsdk:"attached_endpoint_ids"`
+	BilledSizeGb         types.Int64   `tfsdk:"billed_size_gb"`
+	EstimatedMonthlyCost types.Float64 `tfsdk:"estimated_monthly_cost"`
+}
+
+func (d *NetworkVolumeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_volume"
+}
+
+func (d *NetworkVolumeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to look up a single RunPod Network Volume, either by `id` or by `name` and `data_center_id`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the Network Volume. Conflicts with `name` and `data_center_id`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the Network Volume. Must be set together with `data_center_id` and match exactly one volume.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"data_center_id": schema.StringAttribute{
+				MarkdownDescription: "The data center ID where the Network Volume is located. Must be set together with `name`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "The size of the Network Volume in GB.",
+				Computed:            true,
+			},
+			"attached_pod_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the Pods with the Network Volume attached, not including Serverless workers.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"attached_endpoint_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the Serverless Endpoints with the Network Volume attached.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"billed_size_gb": schema.Int64Attribute{
+				MarkdownDescription: "The disk space, in gigabytes (GB), billed for the Network Volume in the most recent billing hour. Null until the volume has been billed.",
+				Computed:            true,
+			},
+			"estimated_monthly_cost": schema.Float64Attribute{
+				MarkdownDescription: "The estimated cost of the Network Volume per month in USD, extrapolated from the most recent billing hour. Null until the volume has been billed.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *NetworkVolumeDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data NetworkVolumeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values may only become known during apply.
+	if data.ID.IsUnknown() || data.Name.IsUnknown() || data.DataCenterId.IsUnknown() {
+		return
+	}
+
+	byName := !data.Name.IsNull() || !data.DataCenterId.IsNull()
+
+	switch {
+	case !data.ID.IsNull() && byName:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Conflicting Network Volume Lookup",
+			"Set either id, or name and data_center_id, but not both.",
+		)
+	case data.ID.IsNull() && !byName:
+		resp.Diagnostics.AddError(
+			"Missing Network Volume Lookup",
+			"Set either id, or name and data_center_id, to look up a Network Volume.",
+		)
+	case byName && (data.Name.IsNull() || data.DataCenterId.IsNull()):
+		resp.Diagnostics.AddError(
+			"Incomplete Network Volume Lookup",
+			"Looking up a Network Volume by name requires both name and data_center_id, since names are not unique.",
+		)
+	}
+}
+
+func (d *NetworkVolumeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NetworkVolumeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NetworkVolumeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading Network Volume data source")
+
+	var volume *NetworkVolume
+	if !data.ID.IsNull() {
+		var err error
+		volume, err = d.client.GetNetworkVolume(ctx, data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read network volume, got error: %s", err))
+			return
+		}
+	} else {
+		volumes, err := d.client.ListNetworkVolumes(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list network volumes, got error: %s", err))
+			return
+		}
+
+		var matches []NetworkVolume
+		for _, vol := range volumes {
+			if vol.Name == data.Name.ValueString() && vol.DataCenterId == data.DataCenterId.ValueString() {
+				matches = append(matches, vol)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			resp.Diagnostics.AddError(
+				"Network Volume Not Found",
+				fmt.Sprintf("No network volume named %q exists in data center %s.", data.Name.ValueString(), data.DataCenterId.ValueString()),
+			)
+			return
+		case 1:
+			volume = &matches[0]
+		default:
+			resp.Diagnostics.AddError(
+				"Ambiguous Network Volume",
+				fmt.Sprintf("%d network volumes named %q exist in data center %s. Look the volume up by id instead.",
+					len(matches), data.Name.ValueString(), data.DataCenterId.ValueString()),
+			)
+			return
+		}
+	}
+
+	data.ID = types.StringValue(volume.ID)
+	data.Name = types.StringValue(volume.Name)
+	data.DataCenterId = types.StringValue(volume.DataCenterId)
+	data.Size = types.Int64Value(int64(volume.Size))
+
+	usage, diags := readNetworkVolumeUsage(ctx, d.client, volume.ID, func() ([]string, []string, error) {
+		return listNetworkVolumeAttachments(ctx, d.client, volume.ID, false)
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.AttachedPodIds = usage.AttachedPodIds
+	data.AttachedEndpointIds = usage.AttachedEndpointIds
+	data.BilledSizeGb = usage.BilledSizeGb
+	data.EstimatedMonthlyCost = usage.EstimatedMonthlyCost
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
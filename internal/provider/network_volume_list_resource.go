@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ list.ListResource = &NetworkVolumeListResource{}
+var _ list.ListResourceWithConfigure = &NetworkVolumeListResource{}
+
+func NewNetworkVolumeListResource() list.ListResource {
+	return &NetworkVolumeListResource{}
+}
+
+// NetworkVolumeListResource lists Network Volumes for terraform query.
+// Metadata and Configure are those of the managed resource.
+type NetworkVolumeListResource struct {
+	NetworkVolumeResource
+}
+
+// NetworkVolumeListResourceModel describes the list resource data model.
+type NetworkVolumeListResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	DataCenterId types.String `tfsdk:"data_center_id"`
+}
+
+func (r *NetworkVolumeListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Network Volumes of the account, optionally filtered, for `terraform query`. " +
+			"The API has no filters for Network Volumes, so they are applied by the provider.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "List only Network Volumes with this name.",
+				Optional:            true,
+			},
+			"data_center_id": schema.StringAttribute{
+				MarkdownDescription: "List only Network Volumes in this data center.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *NetworkVolumeListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	var config NetworkVolumeListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	tflog.Debug(ctx, "Listing Network Volumes")
+
+	volumes, err := r.client.ListNetworkVolumes(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list network volumes, got error: %s", err))
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	resp.Results = func(push func(list.ListResult) bool) {
+		count := 0
+		for _, volume := range volumes {
+			if !config.Name.IsNull() && volume.Name != config.Name.ValueString() {
+				continue
+			}
+			if !config.DataCenterId.IsNull() && volume.DataCenterId != config.DataCenterId.ValueString() {
+				continue
+			}
+			if limitReached(req, count) {
+				return
+			}
+			count++
+
+			var data NetworkVolumeResourceModel
+			result := listResult(ctx, req, &r.NetworkVolumeResource, volume.ID, volume.Name, &data, func() diag.Diagnostics {
+				updateStateFromNetworkVolume(&data, &volume)
+				return r.setUsage(ctx, &data)
+			})
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
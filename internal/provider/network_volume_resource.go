@@ -3,11 +3,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -15,6 +23,7 @@ import (
 
 var _ resource.Resource = &NetworkVolumeResource{}
 var _ resource.ResourceWithImportState = &NetworkVolumeResource{}
+var _ resource.ResourceWithIdentity = &NetworkVolumeResource{}
 
 func NewNetworkVolumeResource() resource.Resource {
 	return &NetworkVolumeResource{}
@@ -22,6 +31,7 @@ func NewNetworkVolumeResource() resource.Resource {
 
 type NetworkVolumeResource struct {
 	client *Client
+	config *providerConfig
 }
 
 type NetworkVolumeResourceModel struct {
@@ -29,8 +39,27 @@ type NetworkVolumeResourceModel struct {
 	Name         types.String `tfsdk:"name"`
 	Size         types.Int64  `tfsdk:"size"`
 	DataCenterId types.String `tfsdk:"data_center_id"`
+
+	AllowReplaceOnShrink types.Bool `tfsdk:"allow_replace_on_shrink"`
+	DeletionProtection   types.Bool `tfsdk:"deletion_protection"`
+
+	// Computed fields
+	AttachedPodIds       types.Set     `tfsdk:"attached_pod_ids"`
+	AttachedEndpointIds  types.Set     `tfsdk:"attached_endpoint_ids"`
+	BilledSizeGb         types.Int64   `tfsdk:"billed_size_gb"`
+	EstimatedMonthlyCost types.Float64 `tfsdk:"estimated_monthly_cost"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
+// defaultNetworkVolumeDeleteTimeout bounds how long Delete waits for Pods and
+// Endpoints to release the Network Volume.
+const defaultNetworkVolumeDeleteTimeout = 10 * time.Minute
+
+// defaultNetworkVolumeUpdateTimeout bounds how long Update waits for a grown
+// Network Volume to report its new size.
+const defaultNetworkVolumeUpdateTimeout = 5 * time.Minute
+
 func (r *NetworkVolumeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_network_volume"
 }
@@ -52,8 +81,11 @@ func (r *NetworkVolumeResource) Schema(ctx context.Context, req resource.SchemaR
 				Required:            true,
 			},
 			"size": schema.Int64Attribute{
-				MarkdownDescription: "The amount of disk space, in gigabytes (GB), allocated to the Network Volume. Must be between 0 and 4000.",
+				MarkdownDescription: "The amount of disk space, in gigabytes (GB), allocated to the Network Volume. Must be between 0 and 4000. Network Volumes can only grow: reducing the size is rejected at plan time unless `allow_replace_on_shrink` is set.",
 				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					networkVolumeSizeModifier{},
+				},
 			},
 			"data_center_id": schema.StringAttribute{
 				MarkdownDescription: "The RunPod data center ID where the Network Volume is located.",
@@ -62,25 +94,78 @@ func (r *NetworkVolumeResource) Schema(ctx context.Context, req resource.SchemaR
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"allow_replace_on_shrink": schema.BoolAttribute{
+				MarkdownDescription: "Whether reducing `size` replaces the Network Volume. Replacement destroys all data stored on the volume. Defaults to `false`, in which case reducing `size` is an error.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether Terraform is prevented from deleting the Network Volume, including when it would be replaced. Defaults to the provider's `deletion_protection` setting.",
+				Optional:            true,
+			},
+			// Computed fields
+			"attached_pod_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the Pods with the Network Volume attached, not including Serverless workers.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"attached_endpoint_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the Serverless Endpoints with the Network Volume attached.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"billed_size_gb": schema.Int64Attribute{
+				MarkdownDescription: "The disk space, in gigabytes (GB), billed for the Network Volume in the most recent billing hour. Null until the volume has been billed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"estimated_monthly_cost": schema.Float64Attribute{
+				MarkdownDescription: "The estimated cost of the Network Volume per month in USD, extrapolated from the most recent billing hour. Null until the volume has been billed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+func (r *NetworkVolumeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema("Network Volume", resp)
+}
+
 func (r *NetworkVolumeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*Client)
+	config, ok := req.ProviderData.(*providerConfig)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = config.client
+	r.config = config
 }
 
 func (r *NetworkVolumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -111,7 +196,13 @@ func (r *NetworkVolumeResource) Create(ctx context.Context, req resource.CreateR
 	data.Size = types.Int64Value(int64(volume.Size))
 	data.DataCenterId = types.StringValue(volume.DataCenterId)
 
+	resp.Diagnostics.Append(r.setUsage(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *NetworkVolumeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -129,12 +220,15 @@ func (r *NetworkVolumeResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	data.ID = types.StringValue(volume.ID)
-	data.Name = types.StringValue(volume.Name)
-	data.Size = types.Int64Value(int64(volume.Size))
-	data.DataCenterId = types.StringValue(volume.DataCenterId)
+	updateStateFromNetworkVolume(&data, volume)
+
+	resp.Diagnostics.Append(r.setUsage(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *NetworkVolumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -146,6 +240,12 @@ func (r *NetworkVolumeResource) Update(ctx context.Context, req resource.UpdateR
 
 	tflog.Debug(ctx, "Updating Network Volume", map[string]interface{}{"id": data.ID.ValueString()})
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultNetworkVolumeUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	input := &NetworkVolumeUpdateInput{
 		Name: data.Name.ValueString(),
 	}
@@ -161,6 +261,14 @@ func (r *NetworkVolumeResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	if input.Size != nil && volume.Size < *input.Size {
+		volume, err = r.waitForSize(ctx, volume.ID, *input.Size, updateTimeout)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resize network volume, got error: %s", err))
+			return
+		}
+	}
+
 	tflog.Trace(ctx, "Updated Network Volume", map[string]interface{}{"id": volume.ID})
 
 	data.ID = types.StringValue(volume.ID)
@@ -168,7 +276,12 @@ func (r *NetworkVolumeResource) Update(ctx context.Context, req resource.UpdateR
 	data.Size = types.Int64Value(int64(volume.Size))
 	data.DataCenterId = types.StringValue(volume.DataCenterId)
 
+	// The usage attributes keep their planned prior values, since attachments
+	// and billing may change between plan and apply. The next refresh
+	// updates them.
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *NetworkVolumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -178,8 +291,30 @@ func (r *NetworkVolumeResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	if r.config.deletionProtected(data.DeletionProtection) {
+		resp.Diagnostics.AddError(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("Network volume %s has deletion protection enabled. "+
+				"Set deletion_protection = false on the resource, or on the provider if the resource does not set it, and apply before deleting it.", data.ID.ValueString()),
+		)
+		return
+	}
+
 	tflog.Debug(ctx, "Deleting Network Volume", map[string]interface{}{"id": data.ID.ValueString()})
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultNetworkVolumeDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Pods and Endpoints destroyed in the same apply may still be releasing
+	// the volume, so wait for them to go away before deleting it.
+	if err := r.waitForDetach(ctx, data.ID.ValueString(), deleteTimeout); err != nil {
+		resp.Diagnostics.AddError("Network Volume In Use", fmt.Sprintf("Unable to delete network volume, got error: %s", err))
+		return
+	}
+
 	err := r.client.DeleteNetworkVolume(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete network volume, got error: %s", err))
@@ -189,6 +324,203 @@ func (r *NetworkVolumeResource) Delete(ctx context.Context, req resource.DeleteR
 	tflog.Trace(ctx, "Deleted Network Volume", map[string]interface{}{"id": data.ID.ValueString()})
 }
 
+// attachedResources describes the Pods, including Serverless workers, and
+// Endpoints that still use the Network Volume.
+func (r *NetworkVolumeResource) attachedResources(ctx context.Context, id string) ([]string, error) {
+	podIds, endpointIds, err := listNetworkVolumeAttachments(ctx, r.client, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var attached []string
+	for _, podId := range podIds {
+		attached = append(attached, "pod "+podId)
+	}
+	for _, endpointId := range endpointIds {
+		attached = append(attached, "endpoint "+endpointId)
+	}
+
+	return attached, nil
+}
+
+// setUsage refreshes the computed usage attributes.
+func (r *NetworkVolumeResource) setUsage(ctx context.Context, data *NetworkVolumeResourceModel) diag.Diagnostics {
+	id := data.ID.ValueString()
+	usage, diags := readNetworkVolumeUsage(ctx, r.client, id, func() ([]string, []string, error) {
+		return r.config.networkVolumeAttachments(ctx, id)
+	})
+
+	data.AttachedPodIds = usage.AttachedPodIds
+	data.AttachedEndpointIds = usage.AttachedEndpointIds
+	data.BilledSizeGb = usage.BilledSizeGb
+	data.EstimatedMonthlyCost = usage.EstimatedMonthlyCost
+
+	return diags
+}
+
+// waitForDetach waits, backing off between checks, until no Pods or
+// Endpoints use the Network Volume.
+func (r *NetworkVolumeResource) waitForDetach(ctx context.Context, id string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	interval := 2 * time.Second
+
+	for {
+		attached, err := r.attachedResources(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error listing resources using network volume: %w", err)
+		}
+		if len(attached) == 0 {
+			return nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for network volume %s to be detached from %s",
+				timeout, id, strings.Join(attached, ", "))
+		}
+
+		tflog.Debug(ctx, "Waiting for Network Volume to be detached", map[string]interface{}{
+			"id":       id,
+			"attached": attached,
+		})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > 30*time.Second {
+			interval = 30 * time.Second
+		}
+	}
+}
+
+// waitForSize polls the Network Volume until the API reports at least the
+// requested size, since growing a volume completes asynchronously.
+func (r *NetworkVolumeResource) waitForSize(ctx context.Context, id string, size int, timeout time.Duration) (*NetworkVolume, error) {
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline:
+			return nil, fmt.Errorf("timed out waiting for network volume %s to grow to %d GB", id, size)
+		case <-ticker.C:
+			volume, err := r.client.GetNetworkVolume(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			tflog.Debug(ctx, "Waiting for Network Volume to grow", map[string]interface{}{
+				"id":        id,
+				"size":      volume.Size,
+				"requested": size,
+			})
+			if volume.Size >= size {
+				return volume, nil
+			}
+		}
+	}
+}
+
+// ImportState imports a Network Volume by ID or identity, by name given as
+// name:<name>, or by data center and name given as <data_center_id>/<name>.
 func (r *NetworkVolumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	var dataCenterId string
+	name, ok := importName(req.ID)
+	if !ok {
+		dataCenterId, name, ok = strings.Cut(req.ID, "/")
+	}
+	if !ok {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		return
+	}
+
+	volumes, err := r.client.ListNetworkVolumes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list network volumes, got error: %s", err))
+		return
+	}
+
+	candidates := make([]importCandidate, 0, len(volumes))
+	for _, volume := range volumes {
+		if dataCenterId == "" || volume.DataCenterId == dataCenterId {
+			candidates = append(candidates, importCandidate{id: volume.ID, name: volume.Name})
+		}
+	}
+
+	kind := "Network Volume"
+	if dataCenterId != "" {
+		kind = fmt.Sprintf("Network Volume in %s", dataCenterId)
+	}
+
+	id, diags := resolveImportName(kind, name, candidates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// updateStateFromNetworkVolume updates the Terraform state from a Network
+// Volume API response.
+func updateStateFromNetworkVolume(data *NetworkVolumeResourceModel, volume *NetworkVolume) {
+	data.ID = types.StringValue(volume.ID)
+	data.Name = types.StringValue(volume.Name)
+	data.Size = types.Int64Value(int64(volume.Size))
+	data.DataCenterId = types.StringValue(volume.DataCenterId)
+
+	if data.AllowReplaceOnShrink.IsNull() {
+		data.AllowReplaceOnShrink = types.BoolValue(false)
+	}
+}
+
+// networkVolumeSizeModifier rejects plans that shrink a Network Volume, which
+// the API does not support. When allow_replace_on_shrink is true the volume
+// is replaced instead.
+type networkVolumeSizeModifier struct{}
+
+func (m networkVolumeSizeModifier) Description(ctx context.Context) string {
+	return "Rejects reducing the size unless allow_replace_on_shrink is set, in which case the resource is replaced."
+}
+
+func (m networkVolumeSizeModifier) MarkdownDescription(ctx context.Context) string {
+	return "Rejects reducing the size unless `allow_replace_on_shrink` is set, in which case the resource is replaced."
+}
+
+func (m networkVolumeSizeModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	// Nothing to compare against on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	if req.StateValue.IsNull() || req.PlanValue.IsUnknown() || req.PlanValue.IsNull() {
+		return
+	}
+	if req.PlanValue.ValueInt64() >= req.StateValue.ValueInt64() {
+		return
+	}
+
+	var allowReplace types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_replace_on_shrink"), &allowReplace)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if allowReplace.ValueBool() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Network Volume Cannot Shrink",
+		fmt.Sprintf("RunPod Network Volumes can only grow, but size would be reduced from %d GB to %d GB. "+
+			"Keep the current size, or set allow_replace_on_shrink = true to replace the volume. "+
+			"Replacing the volume permanently deletes all data stored on it.",
+			req.StateValue.ValueInt64(), req.PlanValue.ValueInt64()),
+	)
 }
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNetworkVolumeSizeModifier(t *testing.T) {
+	ctx := context.Background()
+
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"size":                    schema.Int64Attribute{Required: true},
+			"allow_replace_on_shrink": schema.BoolAttribute{Optional: true, Computed: true},
+		},
+	}
+	objectType := s.Type().TerraformType(ctx)
+
+	object := func(size interface{}, allowReplace bool) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"size":                    tftypes.NewValue(tftypes.Number, size),
+			"allow_replace_on_shrink": tftypes.NewValue(tftypes.Bool, allowReplace),
+		})
+	}
+
+	tests := []struct {
+		name        string
+		state       tftypes.Value
+		plan        tftypes.Value
+		stateSize   types.Int64
+		planSize    types.Int64
+		wantReplace bool
+		wantError   bool
+	}{
+		{
+			name:      "grow",
+			state:     object(10, false),
+			plan:      object(20, false),
+			stateSize: types.Int64Value(10),
+			planSize:  types.Int64Value(20),
+		},
+		{
+			name:      "unchanged",
+			state:     object(10, false),
+			plan:      object(10, false),
+			stateSize: types.Int64Value(10),
+			planSize:  types.Int64Value(10),
+		},
+		{
+			name:      "shrink",
+			state:     object(20, false),
+			plan:      object(10, false),
+			stateSize: types.Int64Value(20),
+			planSize:  types.Int64Value(10),
+			wantError: true,
+		},
+		{
+			name:        "shrink with replace allowed",
+			state:       object(20, false),
+			plan:        object(10, true),
+			stateSize:   types.Int64Value(20),
+			planSize:    types.Int64Value(10),
+			wantReplace: true,
+		},
+		{
+			name:      "create",
+			state:     tftypes.NewValue(objectType, nil),
+			plan:      object(10, false),
+			stateSize: types.Int64Null(),
+			planSize:  types.Int64Value(10),
+		},
+		{
+			name:      "destroy",
+			state:     object(20, false),
+			plan:      tftypes.NewValue(objectType, nil),
+			stateSize: types.Int64Value(20),
+			planSize:  types.Int64Null(),
+		},
+		{
+			name:      "unknown size",
+			state:     object(20, false),
+			plan:      object(tftypes.UnknownValue, false),
+			stateSize: types.Int64Value(20),
+			planSize:  types.Int64Unknown(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := planmodifier.Int64Request{
+				Path:       path.Root("size"),
+				State:      tfsdk.State{Schema: s, Raw: tt.state},
+				Plan:       tfsdk.Plan{Schema: s, Raw: tt.plan},
+				StateValue: tt.stateSize,
+				PlanValue:  tt.planSize,
+			}
+			resp := &planmodifier.Int64Response{PlanValue: tt.planSize}
+
+			networkVolumeSizeModifier{}.PlanModifyInt64(ctx, req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantError {
+				t.Errorf("PlanModifyInt64() error = %t, want %t: %v", got, tt.wantError, resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tt.wantReplace {
+				t.Errorf("PlanModifyInt64() RequiresReplace = %t, want %t", resp.RequiresReplace, tt.wantReplace)
+			}
+		})
+	}
+}
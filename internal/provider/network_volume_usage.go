@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// hoursPerMonth is the average number of hours in a month, used to turn an
+// hourly billing rate into a monthly estimate.
+const hoursPerMonth = 730
+
+// networkVolumeUsage holds the computed usage attributes shared by the
+// runpod_network_volume resource and data source.
+type networkVolumeUsage struct {
+	AttachedPodIds       types.Set
+	AttachedEndpointIds  types.Set
+	BilledSizeGb         types.Int64
+	EstimatedMonthlyCost types.Float64
+}
+
+// listNetworkVolumeAttachments returns the IDs of the Pods and Endpoints with
+// the Network Volume attached. Serverless workers are only listed as Pods
+// when includeWorkers is set.
+func listNetworkVolumeAttachments(ctx context.Context, client *Client, id string, includeWorkers bool) ([]string, []string, error) {
+	podIds := []string{}
+	endpointIds := []string{}
+
+	pods, err := client.ListPods(ctx, &ListPodsFilter{NetworkVolumeId: id, IncludeWorkers: includeWorkers})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, pod := range pods {
+		podIds = append(podIds, pod.ID)
+	}
+
+	endpoints, err := client.ListEndpoints(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.NetworkVolumeId == id {
+			endpointIds = append(endpointIds, endpoint.ID)
+		}
+	}
+
+	return podIds, endpointIds, nil
+}
+
+// networkVolumeAttachments returns the IDs of the Pods, not including
+// Serverless workers, and Endpoints with the Network Volume attached. The
+// Pods and Endpoints of the account are listed at most once per provider
+// run, so that refreshing many volumes does not list them for each.
+func (c *providerConfig) networkVolumeAttachments(ctx context.Context, id string) ([]string, []string, error) {
+	c.attachmentsMu.Lock()
+	defer c.attachmentsMu.Unlock()
+
+	if c.attachedPodsCache == nil {
+		pods, err := c.client.ListPods(ctx, &ListPodsFilter{IncludeDetails: true})
+		if err != nil {
+			return nil, nil, err
+		}
+		endpoints, err := c.client.ListEndpoints(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		attachedPods := map[string][]string{}
+		for _, pod := range pods {
+			if pod.NetworkVolume != nil {
+				attachedPods[pod.NetworkVolume.ID] = append(attachedPods[pod.NetworkVolume.ID], pod.ID)
+			}
+		}
+		attachedEndpoints := map[string][]string{}
+		for _, endpoint := range endpoints {
+			if endpoint.NetworkVolumeId != "" {
+				attachedEndpoints[endpoint.NetworkVolumeId] = append(attachedEndpoints[endpoint.NetworkVolumeId], endpoint.ID)
+			}
+		}
+		c.attachedPodsCache, c.attachedEndpointsCache = attachedPods, attachedEndpoints
+	}
+
+	podIds := append([]string{}, c.attachedPodsCache[id]...)
+	endpointIds := append([]string{}, c.attachedEndpointsCache[id]...)
+	return podIds, endpointIds, nil
+}
+
+// readNetworkVolumeUsage looks up the attachments of a Network Volume with
+// attachments, and its billing. Billing is only a warning when it fails,
+// since the billing API may lag behind or be unavailable to the API key, and
+// leaves the billing attributes null.
+func readNetworkVolumeUsage(ctx context.Context, client *Client, id string, attachments func() ([]string, []string, error)) (networkVolumeUsage, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	usage := networkVolumeUsage{
+		AttachedPodIds:       types.SetNull(types.StringType),
+		AttachedEndpointIds:  types.SetNull(types.StringType),
+		BilledSizeGb:         types.Int64Null(),
+		EstimatedMonthlyCost: types.Float64Null(),
+	}
+
+	podIds, endpointIds, err := attachments()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list resources using network volume, got error: %s", err))
+		return usage, diags
+	}
+
+	var d diag.Diagnostics
+	usage.AttachedPodIds, d = types.SetValueFrom(ctx, types.StringType, podIds)
+	diags.Append(d...)
+	usage.AttachedEndpointIds, d = types.SetValueFrom(ctx, types.StringType, endpointIds)
+	diags.Append(d...)
+
+	records, err := client.GetNetworkVolumeBilling(ctx, id, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		diags.AddWarning("Client Error", fmt.Sprintf("Unable to read network volume billing, got error: %s", err))
+		return usage, diags
+	}
+
+	if latest := latestBillingRecord(records); latest != nil {
+		usage.BilledSizeGb = types.Int64Value(int64(latest.DiskSpaceBilledGb))
+		hourly := latest.Amount / (float64(latest.TimeBilledMs) / float64(time.Hour/time.Millisecond))
+		usage.EstimatedMonthlyCost = types.Float64Value(math.Round(hourly*hoursPerMonth*100) / 100)
+	}
+
+	return usage, diags
+}
+
+// latestBillingRecord returns the most recent record with billed time, or nil
+// when the volume has not been billed yet.
+func latestBillingRecord(records []BillingRecord) *BillingRecord {
+	sort.Slice(records, func(i, j int) bool { return records[i].Time < records[j].Time })
+
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].TimeBilledMs > 0 {
+			return &records[i]
+		}
+	}
+	return nil
+}
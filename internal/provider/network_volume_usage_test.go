@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// countingServer returns a test server answering every request with body,
+// and a counter of the requests it received.
+func countingServer(t *testing.T, body string) (*Client, *atomic.Int64) {
+	t.Helper()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("test")
+	client.BaseURL = server.URL
+	client.GraphQLURL = server.URL + "/graphql"
+	return client, &requests
+}
+
+func TestNetworkVolumeAttachmentsRetryAfterError(t *testing.T) {
+	client, requests := countingServer(t, "[]")
+	config := &providerConfig{client: client}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := config.networkVolumeAttachments(cancelled, "vol1"); err == nil {
+		t.Fatal("networkVolumeAttachments() with a cancelled context succeeded, want an error")
+	}
+
+	ctx := context.Background()
+	if _, _, err := config.networkVolumeAttachments(ctx, "vol1"); err != nil {
+		t.Fatalf("networkVolumeAttachments() after an error = %s, want the listing retried", err)
+	}
+	listed := requests.Load()
+
+	if _, _, err := config.networkVolumeAttachments(ctx, "vol2"); err != nil {
+		t.Fatalf("networkVolumeAttachments() = %s", err)
+	}
+	if got := requests.Load(); got != listed {
+		t.Errorf("networkVolumeAttachments() made %d more requests, want the listing cached", got-listed)
+	}
+}
@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// capacityErrorMessages are fragments of the errors the RunPod API returns
+// when no machine can currently host the requested Pod.
+var capacityErrorMessages = []string{
+	"no longer any instances available",
+	"no instances available",
+	"does not have the resources to deploy",
+	"could not find any pods with required specifications",
+	"out of capacity",
+	"no machines",
+	"not enough free gpus",
+}
+
+// defaultCapacityWaitInterval is how often Pod creation is retried while
+// waiting for capacity, unless capacity_wait sets an interval.
+const defaultCapacityWaitInterval = 30 * time.Second
+
+// errNoCapacity is returned when no spec of a Pod has capacity.
+var errNoCapacity = errors.New("no capacity for any pod spec")
+
+// isCapacityError reports whether err means that the requested GPUs are out
+// of stock, as opposed to a problem with the request itself.
+func isCapacityError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, errNoCapacity) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range capacityErrorMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// PodFallbackModel is an alternative GPU spec tried when the primary spec of
+// a Pod is out of capacity.
+type PodFallbackModel struct {
+	GPUTypeIds    types.List   `tfsdk:"gpu_type_ids"`
+	GPUCount      types.Int64  `tfsdk:"gpu_count"`
+	CloudType     types.String `tfsdk:"cloud_type"`
+	DataCenterIds types.List   `tfsdk:"data_center_ids"`
+}
+
+// PodSelectedSpecModel records the GPU spec a Pod was created with.
+type PodSelectedSpecModel struct {
+	FallbackIndex types.Int64  `tfsdk:"fallback_index"`
+	GPUTypeIds    types.List   `tfsdk:"gpu_type_ids"`
+	GPUCount      types.Int64  `tfsdk:"gpu_count"`
+	CloudType     types.String `tfsdk:"cloud_type"`
+	DataCenterIds types.List   `tfsdk:"data_center_ids"`
+}
+
+var podFallbackAttrTypes = map[string]attr.Type{
+	"gpu_type_ids":    types.ListType{ElemType: types.StringType},
+	"gpu_count":       types.Int64Type,
+	"cloud_type":      types.StringType,
+	"data_center_ids": types.ListType{ElemType: types.StringType},
+}
+
+var podSelectedSpecAttrTypes = map[string]attr.Type{
+	"fallback_index":  types.Int64Type,
+	"gpu_type_ids":    types.ListType{ElemType: types.StringType},
+	"gpu_count":       types.Int64Type,
+	"cloud_type":      types.StringType,
+	"data_center_ids": types.ListType{ElemType: types.StringType},
+}
+
+// podCreateSpec is a candidate GPU spec for creating a Pod.
+type podCreateSpec struct {
+	// fallbackIndex is the index of the fallback block, or -1 for the
+	// primary spec.
+	fallbackIndex int
+	input         *PodCreateInput
+}
+
+// podCreateSpecs returns the primary spec followed by one spec per fallback
+// block. Fallback attributes that are not set are taken from the primary
+// spec.
+func podCreateSpecs(ctx context.Context, data *PodResourceModel, input *PodCreateInput) ([]podCreateSpec, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	specs := []podCreateSpec{{fallbackIndex: -1, input: input}}
+	if data.Fallback.IsNull() || data.Fallback.IsUnknown() {
+		return specs, diags
+	}
+
+	var fallbacks []PodFallbackModel
+	diags.Append(data.Fallback.ElementsAs(ctx, &fallbacks, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for i, fallback := range fallbacks {
+		alternative := *input
+		alternative.GPUTypeIds = nil
+		diags.Append(fallback.GPUTypeIds.ElementsAs(ctx, &alternative.GPUTypeIds, false)...)
+		if !fallback.GPUCount.IsNull() {
+			gpuCount := int(fallback.GPUCount.ValueInt64())
+			alternative.GPUCount = &gpuCount
+		}
+		if !fallback.CloudType.IsNull() {
+			alternative.CloudType = fallback.CloudType.ValueString()
+		}
+		if !fallback.DataCenterIds.IsNull() {
+			alternative.DataCenterIds = nil
+			diags.Append(fallback.DataCenterIds.ElementsAs(ctx, &alternative.DataCenterIds, false)...)
+		}
+		specs = append(specs, podCreateSpec{fallbackIndex: i, input: &alternative})
+	}
+
+	return specs, diags
+}
+
+// createPodWithFallback creates the Pod with the first spec that has
+// capacity. Errors other than capacity errors are returned immediately.
+func (r *PodResource) createPodWithFallback(ctx context.Context, specs []podCreateSpec) (*Pod, podCreateSpec, error) {
+	var failures []string
+
+	for _, spec := range specs {
+		pod, err := r.client.CreatePod(ctx, spec.input)
+		if err == nil {
+			return pod, spec, nil
+		}
+		if !isCapacityError(err) {
+			return nil, spec, err
+		}
+
+		tflog.Info(ctx, "No capacity for Pod spec, trying next fallback", map[string]interface{}{
+			"fallback_index": spec.fallbackIndex,
+			"gpu_type_ids":   spec.input.GPUTypeIds,
+			"error":          err.Error(),
+		})
+		failures = append(failures, fmt.Sprintf("%s: %s", specDescription(spec), err))
+	}
+
+	return nil, podCreateSpec{}, fmt.Errorf("%w:\n%s", errNoCapacity, strings.Join(failures, "\n"))
+}
+
+// PodCapacityWaitModel configures how long Pod creation waits for capacity.
+type PodCapacityWaitModel struct {
+	Timeout  types.String `tfsdk:"timeout"`
+	Interval types.String `tfsdk:"interval"`
+}
+
+// createPodWaitingForCapacity creates the Pod like createPodWithFallback, but
+// when capacity_wait is set it keeps retrying every interval while no spec
+// has capacity, until the timeout passes.
+func (r *PodResource) createPodWaitingForCapacity(ctx context.Context, data *PodResourceModel, specs []podCreateSpec) (*Pod, podCreateSpec, error) {
+	if data.CapacityWait.IsNull() || data.CapacityWait.IsUnknown() {
+		return r.createPodWithFallback(ctx, specs)
+	}
+
+	var wait PodCapacityWaitModel
+	if diags := data.CapacityWait.As(ctx, &wait, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return nil, podCreateSpec{}, fmt.Errorf("unable to read capacity_wait")
+	}
+
+	timeout, err := time.ParseDuration(wait.Timeout.ValueString())
+	if err != nil {
+		return nil, podCreateSpec{}, fmt.Errorf("invalid capacity_wait timeout: %w", err)
+	}
+	interval := defaultCapacityWaitInterval
+	if !wait.Interval.IsNull() {
+		interval, err = time.ParseDuration(wait.Interval.ValueString())
+		if err != nil {
+			return nil, podCreateSpec{}, fmt.Errorf("invalid capacity_wait interval: %w", err)
+		}
+	}
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+
+	for attempt := 1; ; attempt++ {
+		pod, spec, err := r.createPodWithFallback(ctx, specs)
+		if err == nil || !isCapacityError(err) {
+			return pod, spec, err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, spec, fmt.Errorf("still no capacity after waiting %s (%d attempts): %w", timeout, attempt, err)
+		}
+
+		tflog.Info(ctx, "Waiting for GPU capacity", map[string]interface{}{
+			"attempt":   attempt,
+			"elapsed":   time.Since(start).Round(time.Second).String(),
+			"remaining": remaining.Round(time.Second).String(),
+		})
+
+		delay := interval
+		if remaining < delay {
+			delay = remaining
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, spec, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// selectedSpecValue builds the selected_spec attribute for spec.
+func selectedSpecValue(ctx context.Context, spec podCreateSpec) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	selected := PodSelectedSpecModel{
+		FallbackIndex: types.Int64Null(),
+		GPUCount:      types.Int64Null(),
+		CloudType:     types.StringValue(spec.input.CloudType),
+		GPUTypeIds:    types.ListNull(types.StringType),
+		DataCenterIds: types.ListNull(types.StringType),
+	}
+	if spec.fallbackIndex >= 0 {
+		selected.FallbackIndex = types.Int64Value(int64(spec.fallbackIndex))
+	}
+	if spec.input.GPUCount != nil {
+		selected.GPUCount = types.Int64Value(int64(*spec.input.GPUCount))
+	}
+	if spec.input.GPUTypeIds != nil {
+		selected.GPUTypeIds, d = types.ListValueFrom(ctx, types.StringType, spec.input.GPUTypeIds)
+		diags.Append(d...)
+	}
+	if spec.input.DataCenterIds != nil {
+		selected.DataCenterIds, d = types.ListValueFrom(ctx, types.StringType, spec.input.DataCenterIds)
+		diags.Append(d...)
+	}
+
+	value, d := types.ObjectValueFrom(ctx, podSelectedSpecAttrTypes, selected)
+	diags.Append(d...)
+	return value, diags
+}
+
+// usesFallback reports whether the Pod was created from a fallback block, in
+// which case its GPU count and cloud type may differ from the configuration.
+func usesFallback(data *PodResourceModel) bool {
+	if data.SelectedSpec.IsNull() || data.SelectedSpec.IsUnknown() {
+		return false
+	}
+	index, ok := data.SelectedSpec.Attributes()["fallback_index"].(types.Int64)
+	return ok && !index.IsNull()
+}
+
+func specDescription(spec podCreateSpec) string {
+	if spec.fallbackIndex < 0 {
+		return "primary spec"
+	}
+	return fmt.Sprintf("fallback %d", spec.fallbackIndex)
+}
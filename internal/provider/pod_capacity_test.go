@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsCapacityError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"no capacity", errNoCapacity, true},
+		{"wrapped no capacity", fmt.Errorf("%w:\nspec 0: out of capacity", errNoCapacity), true},
+		{"no instances", &APIError{StatusCode: 500, Body: `{"error":"There are no longer any instances available with the requested specifications."}`}, true},
+		{"no resources", &APIError{StatusCode: 400, Body: `{"error":"This machine does not have the resources to deploy your pod."}`}, true},
+		{"mixed case", errors.New("Not Enough Free GPUs on host"), true},
+		{"wrapped api error", fmt.Errorf("create pod: %w", &APIError{StatusCode: 500, Body: "no machines available"}), true},
+		{"invalid request", &APIError{StatusCode: 400, Body: `{"error":"imageName is required"}`}, false},
+		{"unauthorized", &APIError{StatusCode: 401, Body: "unauthorized"}, false},
+		{"network error", errors.New("dial tcp: connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCapacityError(tt.err); got != tt.want {
+				t.Errorf("isCapacityError(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
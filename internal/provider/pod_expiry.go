@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// podExpiryEnvVar records the expiry of a Pod with a ttl on the Pod itself,
+// so that runpod_expired_pods can find it without access to Terraform state.
+// It is hidden from the env attribute.
+const podExpiryEnvVar = "TERRAFORM_RUNPOD_EXPIRES_AT"
+
+// podCreatedAtKey is the private state key holding the time the provider
+// created a Pod, from which its expiry is computed when ttl changes.
+const podCreatedAtKey = "created_at"
+
+// podExpiresAtKey is the private state key holding the expiry of a Pod, or
+// an empty string when it has none. Updating the environment of a Pod
+// restarts it, so the expiry on the Pod is only updated along with other
+// changes, and the one recorded here takes precedence.
+const podExpiresAtKey = "expires_at"
+
+// privateState is the private state of a resource, as found on the requests
+// and responses of the framework.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateSetter is the private state of a resource response.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// podExpiry returns the expiry recorded on a Pod, if any.
+func podExpiry(pod *Pod) (time.Time, bool) {
+	value, ok := pod.Env[podExpiryEnvVar]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiry, true
+}
+
+// recordExpiry records expiresAt in private state.
+func recordExpiry(ctx context.Context, private privateStateSetter, expiresAt types.String) diag.Diagnostics {
+	raw, _ := json.Marshal(expiresAt.ValueString())
+	return private.SetKey(ctx, podExpiresAtKey, raw)
+}
+
+// refreshRecordedExpiry sets expires_at from the expiry recorded in private
+// state. Pods without one, e.g. after import, keep the expiry found on the
+// Pod.
+func refreshRecordedExpiry(ctx context.Context, private privateState, data *PodResourceModel) diag.Diagnostics {
+	raw, diags := private.GetKey(ctx, podExpiresAtKey)
+	if len(raw) == 0 {
+		return diags
+	}
+
+	var expiresAt string
+	if err := json.Unmarshal(raw, &expiresAt); err != nil {
+		diags.AddError("Internal Error", fmt.Sprintf("Unable to decode recorded pod expiry, got error: %s", err))
+		return diags
+	}
+
+	if expiresAt == "" {
+		data.ExpiresAt = types.StringNull()
+	} else {
+		data.ExpiresAt = types.StringValue(expiresAt)
+	}
+	return diags
+}
+
+// expiryEnv returns env with the expiry of the Pod added, or removed when the
+// Pod has none.
+func expiryEnv(env map[string]string, expiresAt types.String) map[string]string {
+	if expiresAt.IsNull() || expiresAt.IsUnknown() {
+		delete(env, podExpiryEnvVar)
+		return env
+	}
+
+	if env == nil {
+		env = map[string]string{}
+	}
+	env[podExpiryEnvVar] = expiresAt.ValueString()
+	return env
+}
+
+// planExpiry plans expires_at from ttl and, once the expiry has passed,
+// plans stopping the Pod by setting desired_status to EXITED.
+func (r *PodResource) planExpiry(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var ttl, expiresAt types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case ttl.IsNull():
+		expiresAt = types.StringNull()
+	case ttl.IsUnknown() || req.State.Raw.IsNull():
+		// The expiry of a new Pod is only known once it has been created.
+		expiresAt = types.StringUnknown()
+	default:
+		var priorTTL types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("ttl"), &priorTTL)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expires_at"), &expiresAt)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !priorTTL.Equal(ttl) || expiresAt.IsNull() {
+			expiresAt = types.StringUnknown()
+
+			// The ttl counts from creation, when the provider recorded it.
+			raw, diags := req.Private.GetKey(ctx, podCreatedAtKey)
+			resp.Diagnostics.Append(diags...)
+
+			var createdAt time.Time
+			if raw != nil && json.Unmarshal(raw, &createdAt) == nil {
+				if d, err := time.ParseDuration(ttl.ValueString()); err == nil {
+					expiresAt = types.StringValue(createdAt.Add(d).UTC().Format(time.RFC3339))
+				}
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_at"), expiresAt)...)
+
+	if expiresAt.IsNull() || expiresAt.IsUnknown() || req.State.Raw.IsNull() {
+		return
+	}
+
+	expiry, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	if err != nil || time.Now().Before(expiry) {
+		return
+	}
+
+	var desiredStatus types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("desired_status"), &desiredStatus)...)
+	if desiredStatus.ValueString() == "EXITED" || desiredStatus.ValueString() == "TERMINATED" {
+		return
+	}
+
+	tflog.Debug(ctx, "Pod expired", map[string]interface{}{"expires_at": expiresAt.ValueString()})
+
+	resp.Diagnostics.AddWarning(
+		"Pod Expired",
+		fmt.Sprintf("The pod expired at %s, as set by its ttl, and will be stopped. Increase or remove ttl to keep it running.", expiresAt.ValueString()),
+	)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("desired_status"), types.StringValue("EXITED"))...)
+}
+
+var _ validator.String = durationValidator{}
+
+// durationValidator checks that a string is a positive Go duration.
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as 30m or 72h"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a positive duration such as `30m` or `72h`"
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%q is not a positive duration. Use a number with a unit suffix such as \"30m\" or \"72h\".", req.ConfigValue.ValueString()),
+		)
+	}
+}
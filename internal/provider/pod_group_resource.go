@@ -0,0 +1,631 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &PodGroupResource{}
+var _ resource.ResourceWithValidateConfig = &PodGroupResource{}
+var _ resource.ResourceWithModifyPlan = &PodGroupResource{}
+
+func NewPodGroupResource() resource.Resource {
+	return &PodGroupResource{}
+}
+
+type PodGroupResource struct {
+	client *Client
+	config *providerConfig
+}
+
+type PodGroupResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Replicas          types.Int64  `tfsdk:"replicas"`
+	MinReplicas       types.Int64  `tfsdk:"min_replicas"`
+	ImageName         types.String `tfsdk:"image_name"`
+	TemplateId        types.String `tfsdk:"template_id"`
+	CloudType         types.String `tfsdk:"cloud_type"`
+	GPUCount          types.Int64  `tfsdk:"gpu_count"`
+	GPUTypeIds        types.Set    `tfsdk:"gpu_type_ids"`
+	DataCenterIds     types.Set    `tfsdk:"data_center_ids"`
+	ContainerDiskInGb types.Int64  `tfsdk:"container_disk_in_gb"`
+	VolumeInGb        types.Int64  `tfsdk:"volume_in_gb"`
+	VolumeMountPath   types.String `tfsdk:"volume_mount_path"`
+	Ports             types.List   `tfsdk:"ports"`
+	Env               types.Map    `tfsdk:"env"`
+	NetworkVolumeId   types.String `tfsdk:"network_volume_id"`
+	Interruptible     types.Bool   `tfsdk:"interruptible"`
+	// Computed fields
+	Pods types.List `tfsdk:"pods"`
+}
+
+// PodGroupReplicaModel is a Pod of a runpod_pod_group.
+type PodGroupReplicaModel struct {
+	Index    types.Int64  `tfsdk:"index"`
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	PublicIp types.String `tfsdk:"public_ip"`
+}
+
+var podGroupReplicaAttrTypes = map[string]attr.Type{
+	"index":     types.Int64Type,
+	"id":        types.StringType,
+	"name":      types.StringType,
+	"public_ip": types.StringType,
+}
+
+func (r *PodGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pod_group"
+}
+
+func (r *PodGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "RunPod Pod Group resource. A Pod Group is a set of identical GPU Pods, for example the nodes of a training cluster, " +
+			"that is created all-or-nothing: if fewer than `min_replicas` Pods can be scheduled, every Pod created is deleted again. " +
+			"Changing `replicas` only creates or deletes the difference. Changing any other attribute replaces the whole group.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the Pod Group, generated by the provider.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the Pod Group. Each Pod is named after the group and its index, e.g. `trainer-0`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"replicas": schema.Int64Attribute{
+				MarkdownDescription: "The number of Pods in the group.",
+				Required:            true,
+			},
+			"min_replicas": schema.Int64Attribute{
+				MarkdownDescription: "The minimum number of Pods that must be scheduled. If fewer can be created, the Pods created by the apply are deleted again and the apply fails. Defaults to `replicas`.",
+				Optional:            true,
+			},
+			"image_name": schema.StringAttribute{
+				MarkdownDescription: "The Docker image tag for the container run on each Pod.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"template_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the template used to create each Pod.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cloud_type": schema.StringAttribute{
+				MarkdownDescription: "Set to SECURE to create the Pods in Secure Cloud. Set to COMMUNITY to create the Pods in Community Cloud.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("SECURE"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gpu_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of GPUs attached to each Pod.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"gpu_type_ids": schema.SetAttribute{
+				MarkdownDescription: "A set of RunPod GPU types which can be attached to the Pods.",
+				ElementType:         types.StringType,
+				Required:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"data_center_ids": schema.SetAttribute{
+				MarkdownDescription: "A set of RunPod data center IDs where the Pods can be located.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"container_disk_in_gb": schema.Int64Attribute{
+				MarkdownDescription: "The amount of disk space, in gigabytes (GB), to allocate on the container disk of each Pod.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(50),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"volume_in_gb": schema.Int64Attribute{
+				MarkdownDescription: "The amount of disk space, in gigabytes (GB), to allocate on the volume of each Pod.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(20),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"volume_mount_path": schema.StringAttribute{
+				MarkdownDescription: "The absolute path where the volume will be mounted in the filesystem of each Pod.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("/workspace"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ports": schema.ListAttribute{
+				MarkdownDescription: "A list of ports exposed on each Pod, e.g. `8888/http` or `22/tcp`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"env": schema.MapAttribute{
+				MarkdownDescription: "Environment variables set on each Pod. Each Pod also gets `POD_GROUP_INDEX` and `POD_GROUP_SIZE`. `POD_GROUP_SIZE` is the value of `replicas` when the Pod was created; Pods are not updated when the group is scaled, so older Pods keep reporting the previous size. Values may reference RunPod Secrets, e.g. `runpod_secret.example.reference`; referenced Secrets must exist or be managed in the same configuration.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"network_volume_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of a network volume to attach to each Pod.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"interruptible": schema.BoolAttribute{
+				MarkdownDescription: "Set to true to create interruptible Pods.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			// Computed fields
+			"pods": schema.ListNestedAttribute{
+				MarkdownDescription: "The Pods of the group, ordered by index.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"index": schema.Int64Attribute{
+							MarkdownDescription: "The index of the Pod within the group.",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the Pod.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the Pod.",
+							Computed:            true,
+						},
+						"public_ip": schema.StringAttribute{
+							MarkdownDescription: "The public IP address of the Pod.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *PodGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PodGroupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Replicas.IsNull() && !data.Replicas.IsUnknown() && data.Replicas.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("replicas"), "Invalid Replicas", "replicas must be at least 1.")
+	}
+
+	if data.Replicas.IsUnknown() || data.MinReplicas.IsNull() || data.MinReplicas.IsUnknown() {
+		return
+	}
+
+	if data.MinReplicas.ValueInt64() < 0 || data.MinReplicas.ValueInt64() > data.Replicas.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("min_replicas"),
+			"Invalid Minimum Replicas",
+			fmt.Sprintf("min_replicas must be between 0 and replicas (%d).", data.Replicas.ValueInt64()),
+		)
+	}
+}
+
+// ModifyPlan checks that the secrets referenced in env exist or are managed
+// in the same plan.
+func (r *PodGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.config == nil {
+		return
+	}
+
+	var env types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("env"), &env)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.config.validateSecretReferences(ctx, env, path.Root("env"))...)
+}
+
+func (r *PodGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*providerConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.client
+	r.config = config
+}
+
+func (r *PodGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PodGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to generate pod group ID, got error: %s", err))
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	tflog.Debug(ctx, "Creating Pod Group", map[string]interface{}{"id": id, "replicas": data.Replicas.ValueInt64()})
+
+	replicas, diags := r.scaleUp(ctx, &data, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Created Pod Group", map[string]interface{}{"id": id, "pods": len(replicas)})
+
+	resp.Diagnostics.Append(r.setPods(ctx, &data, replicas)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PodGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PodGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading Pod Group", map[string]interface{}{"id": data.ID.ValueString()})
+
+	replicas, diags := r.replicas(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Pods deleted outside of Terraform show up as a lower replica count, so
+	// the next apply creates them again. Any other error fails the refresh,
+	// so that an API outage does not drop the group from state.
+	var current []PodGroupReplicaModel
+	for _, replica := range replicas {
+		pod, err := r.client.GetPod(ctx, replica.ID.ValueString())
+		if isNotFound(err) {
+			tflog.Debug(ctx, "Pod Group replica not found", map[string]interface{}{"id": replica.ID.ValueString()})
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read pod group replica %s, got error: %s", replica.ID.ValueString(), err))
+			return
+		}
+		current = append(current, podGroupReplica(replica.Index.ValueInt64(), pod))
+	}
+
+	if len(current) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Replicas = types.Int64Value(int64(len(current)))
+
+	resp.Diagnostics.Append(r.setPods(ctx, &data, current)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PodGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state PodGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating Pod Group", map[string]interface{}{"id": data.ID.ValueString(), "replicas": data.Replicas.ValueInt64()})
+
+	replicas, diags := r.replicas(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	want := int(data.Replicas.ValueInt64())
+	switch {
+	case want > len(replicas):
+		replicas, diags = r.scaleUp(ctx, &data, replicas)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	case want < len(replicas):
+		// Remove the highest indices first so the remaining Pods keep theirs.
+		for len(replicas) > want {
+			last := replicas[len(replicas)-1]
+			if err := r.client.DeletePod(ctx, last.ID.ValueString()); err != nil && !isNotFound(err) {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete pod %s, got error: %s", last.ID.ValueString(), err))
+				break
+			}
+			replicas = replicas[:len(replicas)-1]
+		}
+	}
+
+	tflog.Trace(ctx, "Updated Pod Group", map[string]interface{}{"id": data.ID.ValueString(), "pods": len(replicas)})
+
+	// Record what exists even if scaling down failed part way.
+	if resp.Diagnostics.HasError() {
+		state.Replicas = types.Int64Value(int64(len(replicas)))
+		resp.Diagnostics.Append(r.setPods(ctx, &state, replicas)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	resp.Diagnostics.Append(r.setPods(ctx, &data, replicas)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PodGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PodGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting Pod Group", map[string]interface{}{"id": data.ID.ValueString()})
+
+	replicas, diags := r.replicas(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, replica := range replicas {
+		// Pods deleted outside of Terraform are already gone.
+		if err := r.client.DeletePod(ctx, replica.ID.ValueString()); err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete pod %s, got error: %s", replica.ID.ValueString(), err))
+		}
+	}
+
+	tflog.Trace(ctx, "Deleted Pod Group", map[string]interface{}{"id": data.ID.ValueString()})
+}
+
+// scaleUp creates Pods until the group has the planned number of replicas.
+// When the group would end up with fewer than min_replicas Pods, the Pods
+// created by this call are deleted again and an error is returned.
+func (r *PodGroupResource) scaleUp(ctx context.Context, data *PodGroupResourceModel, existing []PodGroupReplicaModel) ([]PodGroupReplicaModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	want := int(data.Replicas.ValueInt64())
+	minReplicas := want
+	if !data.MinReplicas.IsNull() {
+		minReplicas = int(data.MinReplicas.ValueInt64())
+	}
+
+	// New Pods take the lowest free indices.
+	used := map[int64]bool{}
+	for _, replica := range existing {
+		used[replica.Index.ValueInt64()] = true
+	}
+
+	replicas := existing
+	var created []PodGroupReplicaModel
+	var failures []string
+
+	for index := int64(0); len(replicas)+len(failures) < want; index++ {
+		if used[index] {
+			continue
+		}
+
+		input, d := r.podCreateInput(ctx, data, index)
+		diags.Append(d...)
+		if diags.HasError() {
+			break
+		}
+
+		pod, err := r.client.CreatePod(ctx, input)
+		if err != nil {
+			tflog.Debug(ctx, "Unable to create Pod Group replica", map[string]interface{}{"index": index, "error": err.Error()})
+			failures = append(failures, fmt.Sprintf("replica %d: %s", index, err))
+			continue
+		}
+
+		if reason := r.overBudget(pod); reason != "" {
+			if err := r.client.DeletePod(ctx, pod.ID); err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to delete pod %s after a failed cost check, got error: %s. Delete the pod manually to stop being charged for it.", pod.ID, err))
+			}
+			failures = append(failures, fmt.Sprintf("replica %d: %s", index, reason))
+			continue
+		}
+
+		replica := podGroupReplica(index, pod)
+		created = append(created, replica)
+		replicas = append(replicas, replica)
+	}
+
+	if len(replicas) < minReplicas || diags.HasError() {
+		for _, replica := range created {
+			if err := r.client.DeletePod(ctx, replica.ID.ValueString()); err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to delete pod %s while rolling back, got error: %s. Delete the pod manually to stop being charged for it.", replica.ID.ValueString(), err))
+			}
+		}
+
+		diags.AddError(
+			"Insufficient Pod Group Replicas",
+			fmt.Sprintf("Only %d of the %d pods could be scheduled, fewer than min_replicas (%d). The %d pods created by this apply have been deleted.\n\n%s",
+				len(replicas), want, minReplicas, len(created), strings.Join(failures, "\n")),
+		)
+		return nil, diags
+	}
+
+	if len(failures) > 0 {
+		diags.AddWarning(
+			"Pod Group Partially Scheduled",
+			fmt.Sprintf("%d of the %d pods were scheduled. The next apply will try to create the remaining pods.\n\n%s",
+				len(replicas), want, strings.Join(failures, "\n")),
+		)
+	}
+
+	return replicas, diags
+}
+
+// overBudget checks a new Pod against the provider's max_total_cost_per_hr,
+// returning why it must be deleted, or an empty string.
+func (r *PodGroupResource) overBudget(pod *Pod) string {
+	if r.config.maxTotalCostPerHr == nil {
+		return ""
+	}
+
+	cost := pod.AdjustedCostPerHr
+	if cost == nil {
+		cost = pod.CostPerHr
+	}
+	if cost == nil {
+		return "the RunPod API did not report the cost of the pod"
+	}
+
+	if total, ok := r.config.reserveCost(*cost); !ok {
+		return fmt.Sprintf("the pods created in this run would cost $%.3f per hour, more than max_total_cost_per_hr of $%.3f", total, *r.config.maxTotalCostPerHr)
+	}
+	return ""
+}
+
+// podCreateInput builds the request creating the Pod with the given index.
+func (r *PodGroupResource) podCreateInput(ctx context.Context, data *PodGroupResourceModel, index int64) (*PodCreateInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	gpuCount := int(data.GPUCount.ValueInt64())
+	containerDisk := int(data.ContainerDiskInGb.ValueInt64())
+	volume := int(data.VolumeInGb.ValueInt64())
+	interruptible := data.Interruptible.ValueBool()
+
+	input := &PodCreateInput{
+		Name:              r.config.prefixedName(fmt.Sprintf("%s-%d", data.Name.ValueString(), index)),
+		ImageName:         data.ImageName.ValueString(),
+		TemplateId:        data.TemplateId.ValueString(),
+		ComputeType:       "GPU",
+		CloudType:         data.CloudType.ValueString(),
+		GPUCount:          &gpuCount,
+		ContainerDiskInGb: &containerDisk,
+		VolumeInGb:        &volume,
+		VolumeMountPath:   data.VolumeMountPath.ValueString(),
+		NetworkVolumeId:   data.NetworkVolumeId.ValueString(),
+		Interruptible:     &interruptible,
+	}
+
+	diags.Append(data.GPUTypeIds.ElementsAs(ctx, &input.GPUTypeIds, false)...)
+	if !data.DataCenterIds.IsNull() {
+		diags.Append(data.DataCenterIds.ElementsAs(ctx, &input.DataCenterIds, false)...)
+	}
+	if !data.Ports.IsNull() {
+		diags.Append(data.Ports.ElementsAs(ctx, &input.Ports, false)...)
+	}
+
+	var env map[string]string
+	if !data.Env.IsNull() {
+		diags.Append(data.Env.ElementsAs(ctx, &env, false)...)
+	}
+	env = r.config.mergedEnv(env)
+	if env == nil {
+		env = map[string]string{}
+	}
+	// Scaling leaves existing Pods alone, since updating their environment
+	// would restart them, so POD_GROUP_SIZE is the size at creation.
+	env["POD_GROUP_INDEX"] = strconv.FormatInt(index, 10)
+	env["POD_GROUP_SIZE"] = strconv.FormatInt(data.Replicas.ValueInt64(), 10)
+	input.Env = env
+
+	return input, diags
+}
+
+// replicas returns the Pods recorded in state, ordered by index.
+func (r *PodGroupResource) replicas(ctx context.Context, data *PodGroupResourceModel) ([]PodGroupReplicaModel, diag.Diagnostics) {
+	var replicas []PodGroupReplicaModel
+	if data.Pods.IsNull() || data.Pods.IsUnknown() {
+		return replicas, nil
+	}
+
+	diags := data.Pods.ElementsAs(ctx, &replicas, false)
+	sort.Slice(replicas, func(i, j int) bool { return replicas[i].Index.ValueInt64() < replicas[j].Index.ValueInt64() })
+	return replicas, diags
+}
+
+// setPods records the Pods of the group in data.
+func (r *PodGroupResource) setPods(ctx context.Context, data *PodGroupResourceModel, replicas []PodGroupReplicaModel) diag.Diagnostics {
+	if replicas == nil {
+		replicas = []PodGroupReplicaModel{}
+	}
+
+	var diags diag.Diagnostics
+	data.Pods, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: podGroupReplicaAttrTypes}, replicas)
+	return diags
+}
+
+func podGroupReplica(index int64, pod *Pod) PodGroupReplicaModel {
+	return PodGroupReplicaModel{
+		Index:    types.Int64Value(index),
+		ID:       types.StringValue(pod.ID),
+		Name:     types.StringValue(pod.Name),
+		PublicIp: types.StringValue(pod.PublicIp),
+	}
+}
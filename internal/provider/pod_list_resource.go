@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ list.ListResource = &PodListResource{}
+var _ list.ListResourceWithConfigure = &PodListResource{}
+
+func NewPodListResource() list.ListResource {
+	return &PodListResource{}
+}
+
+// PodListResource lists Pods for terraform query. Metadata and Configure are
+// those of the managed resource.
+type PodListResource struct {
+	PodResource
+}
+
+// PodListResourceModel describes the list resource data model.
+type PodListResourceModel struct {
+	Name            types.String `tfsdk:"name"`
+	ComputeType     types.String `tfsdk:"compute_type"`
+	DesiredStatus   types.String `tfsdk:"desired_status"`
+	ImageName       types.String `tfsdk:"image_name"`
+	TemplateId      types.String `tfsdk:"template_id"`
+	NetworkVolumeId types.String `tfsdk:"network_volume_id"`
+	GPUTypeIds      types.List   `tfsdk:"gpu_type_ids"`
+	DataCenterIds   types.List   `tfsdk:"data_center_ids"`
+}
+
+func (r *PodListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Pods of the account, optionally filtered, for `terraform query`. Serverless workers are not listed.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "List only Pods with this name. The provider's `name_prefix` is prepended.",
+				Optional:            true,
+			},
+			"compute_type": schema.StringAttribute{
+				MarkdownDescription: "List only GPU or CPU Pods. Either `GPU` or `CPU`.",
+				Optional:            true,
+				Validators: []validator.String{
+					oneOfValidator{values: []string{"GPU", "CPU"}},
+				},
+			},
+			"desired_status": schema.StringAttribute{
+				MarkdownDescription: "List only Pods with this status. One of `RUNNING`, `EXITED` or `TERMINATED`.",
+				Optional:            true,
+				Validators: []validator.String{
+					oneOfValidator{values: []string{"RUNNING", "EXITED", "TERMINATED"}},
+				},
+			},
+			"image_name": schema.StringAttribute{
+				MarkdownDescription: "List only Pods running this Docker image.",
+				Optional:            true,
+			},
+			"template_id": schema.StringAttribute{
+				MarkdownDescription: "List only Pods created from this Template.",
+				Optional:            true,
+			},
+			"network_volume_id": schema.StringAttribute{
+				MarkdownDescription: "List only Pods with this Network Volume attached.",
+				Optional:            true,
+			},
+			"gpu_type_ids": schema.ListAttribute{
+				MarkdownDescription: "List only Pods with any of these GPU types attached.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"data_center_ids": schema.ListAttribute{
+				MarkdownDescription: "List only Pods in any of these data centers.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *PodListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	var config PodListResourceModel
+	diags := req.Config.Get(ctx, &config)
+
+	filter := &ListPodsFilter{
+		NetworkVolumeId: config.NetworkVolumeId.ValueString(),
+		ComputeType:     config.ComputeType.ValueString(),
+		DesiredStatus:   config.DesiredStatus.ValueString(),
+		ImageName:       config.ImageName.ValueString(),
+		TemplateId:      config.TemplateId.ValueString(),
+		IncludeDetails:  true,
+	}
+	if !config.Name.IsNull() {
+		filter.Name = r.config.prefixedName(config.Name.ValueString())
+	}
+	if !config.GPUTypeIds.IsNull() {
+		diags.Append(config.GPUTypeIds.ElementsAs(ctx, &filter.GPUTypeIds, false)...)
+	}
+	if !config.DataCenterIds.IsNull() {
+		diags.Append(config.DataCenterIds.ElementsAs(ctx, &filter.DataCenterIds, false)...)
+	}
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	tflog.Debug(ctx, "Listing Pods", map[string]interface{}{"name": filter.Name})
+
+	pods, err := r.client.ListPods(ctx, filter)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list pods, got error: %s", err))
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	resp.Results = func(push func(list.ListResult) bool) {
+		for i, pod := range pods {
+			if limitReached(req, i) {
+				return
+			}
+
+			var data PodResourceModel
+			result := listResult(ctx, req, &r.PodResource, pod.ID, pod.Name, &data, func() diag.Diagnostics {
+				diags := r.updateStateFromPod(ctx, &data, &pod, nil)
+				return append(diags, adoptPodPlacement(ctx, &data, &pod)...)
+			})
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
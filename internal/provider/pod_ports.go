@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// podProxyURLFormat is the URL of an HTTP port of a Pod behind the RunPod
+// proxy, from the Pod ID and the internal port.
+const podProxyURLFormat = "https://%s-%d.proxy.runpod.net"
+
+// podEndpoints returns the port_mappings, http_urls and ssh_command of a Pod.
+func podEndpoints(ctx context.Context, pod *Pod) (types.Map, types.Map, types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	mappings := map[string]int64{}
+	for internal, public := range pod.PortMappings {
+		mappings[internal] = int64(public)
+	}
+
+	urls := map[string]string{}
+	for _, port := range pod.Ports {
+		number, protocol, ok := parsePort(port)
+		if ok && protocol == "http" {
+			urls[strconv.Itoa(number)] = fmt.Sprintf(podProxyURLFormat, pod.ID, number)
+		}
+	}
+
+	sshCommand := types.StringNull()
+	if public, ok := pod.PortMappings["22"]; ok && pod.PublicIp != "" {
+		sshCommand = types.StringValue(fmt.Sprintf("ssh root@%s -p %d", pod.PublicIp, public))
+	}
+
+	portMappings, d := types.MapValueFrom(ctx, types.Int64Type, mappings)
+	diags.Append(d...)
+	httpURLs, d := types.MapValueFrom(ctx, types.StringType, urls)
+	diags.Append(d...)
+
+	return portMappings, httpURLs, sshCommand, diags
+}
+
+// parsePort splits a port such as "8888/http" into its number and protocol.
+func parsePort(port string) (int, string, bool) {
+	number, protocol, found := strings.Cut(port, "/")
+	if !found {
+		return 0, "", false
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(number))
+	if err != nil {
+		return 0, "", false
+	}
+	return n, strings.ToLower(strings.TrimSpace(protocol)), true
+}
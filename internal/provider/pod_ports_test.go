@@ -0,0 +1,30 @@
+package provider
+
+import "testing"
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		port         string
+		wantNumber   int
+		wantProtocol string
+		wantOk       bool
+	}{
+		{"8888/http", 8888, "http", true},
+		{"22/tcp", 22, "tcp", true},
+		{" 8080 / HTTP ", 8080, "http", true},
+		{"8888", 0, "", false},
+		{"http/8888", 0, "", false},
+		{"/http", 0, "", false},
+		{"", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.port, func(t *testing.T) {
+			number, protocol, ok := parsePort(tt.port)
+			if number != tt.wantNumber || protocol != tt.wantProtocol || ok != tt.wantOk {
+				t.Errorf("parsePort(%q) = %d, %q, %t, want %d, %q, %t",
+					tt.port, number, protocol, ok, tt.wantNumber, tt.wantProtocol, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// podStoppedStatusChangeKey is the private state key holding the
+	// lastStatusChange the API reported after Terraform stopped the Pod.
+	podStoppedStatusChangeKey = "stopped_status_change"
+
+	onPreemptionIgnore   = "ignore"
+	onPreemptionRestart  = "restart"
+	onPreemptionRecreate = "recreate"
+)
+
+// preemptionStatusChanges are the prefixes of the lastStatusChange the API
+// reports when RunPod stops an interruptible Pod, e.g.
+// "Outbid by system: Fri Jul 12 2024 15:14:40 GMT-0400".
+var preemptionStatusChanges = []string{
+	"outbid",
+	"interrupted",
+	"preempted",
+	"stopped by system",
+	"exited by system",
+}
+
+// podPreempted reports whether RunPod stopped an interruptible Pod. Only a
+// lastStatusChange known to mean preemption counts, so that a stop by a user,
+// by Terraform with the given lastStatusChange, or one described in words
+// this provider does not know, is never mistaken for one and does not
+// trigger on_preemption.
+func podPreempted(pod *Pod, stoppedByTerraform string) bool {
+	if pod.DesiredStatus != "EXITED" || pod.Interruptible == nil || !*pod.Interruptible {
+		return false
+	}
+	if pod.LastStatusChange == "" || pod.LastStatusChange == stoppedByTerraform {
+		return false
+	}
+
+	change := strings.ToLower(strings.TrimSpace(pod.LastStatusChange))
+	for _, prefix := range preemptionStatusChanges {
+		if strings.HasPrefix(change, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshPreempted sets preempted from the Pod and the stop Terraform
+// recorded in private state.
+func (r *PodResource) refreshPreempted(ctx context.Context, data *PodResourceModel, pod *Pod, private privateState) diag.Diagnostics {
+	var stopped string
+
+	raw, diags := private.GetKey(ctx, podStoppedStatusChangeKey)
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &stopped); err != nil {
+			diags.AddError("Internal Error", fmt.Sprintf("Unable to decode recorded pod stop, got error: %s", err))
+		}
+	}
+
+	data.Preempted = types.BoolValue(podPreempted(pod, stopped))
+	if data.Preempted.ValueBool() {
+		tflog.Info(ctx, "Pod was preempted", map[string]interface{}{"id": pod.ID, "last_status_change": pod.LastStatusChange})
+	}
+	return diags
+}
+
+// planPreemption plans the response to a preempted Pod according to
+// on_preemption: nothing, starting it again, or replacing it.
+func (r *PodResource) planPreemption(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state, plan PodResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !state.Preempted.ValueBool() {
+		return
+	}
+
+	switch plan.OnPreemption.ValueString() {
+	case onPreemptionRestart:
+		// An expired Pod stays stopped.
+		if !state.ExpiresAt.IsNull() {
+			if expiresAt, err := time.Parse(time.RFC3339, state.ExpiresAt.ValueString()); err == nil && time.Now().After(expiresAt) {
+				return
+			}
+		}
+		resp.Diagnostics.AddWarning(
+			"Pod Preempted",
+			fmt.Sprintf("Pod %s was stopped by RunPod and will be started again.", state.ID.ValueString()),
+		)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("desired_status"), types.StringValue("RUNNING"))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("preempted"), types.BoolValue(false))...)
+	case onPreemptionRecreate:
+		resp.Diagnostics.AddWarning(
+			"Pod Preempted",
+			fmt.Sprintf("Pod %s was stopped by RunPod and will be replaced.", state.ID.ValueString()),
+		)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("preempted"), types.BoolValue(false))...)
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("preempted"))
+	}
+}
+
+var _ validator.String = oneOfValidator{}
+
+// oneOfValidator checks that a string is one of a fixed set of values.
+type oneOfValidator struct {
+	values []string
+}
+
+func (v oneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v oneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: `%s`", strings.Join(v.values, "`, `"))
+}
+
+func (v oneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, value := range v.values {
+		if req.ConfigValue.ValueString() == value {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Value",
+		fmt.Sprintf("%q is not valid. Use one of: %s.", req.ConfigValue.ValueString(), strings.Join(v.values, ", ")),
+	)
+}
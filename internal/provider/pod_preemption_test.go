@@ -0,0 +1,44 @@
+package provider
+
+import "testing"
+
+func TestPodPreempted(t *testing.T) {
+	interruptible := true
+	onDemand := false
+
+	tests := []struct {
+		name             string
+		desiredStatus    string
+		interruptible    *bool
+		lastStatusChange string
+		stopped          string
+		want             bool
+	}{
+		{"outbid", "EXITED", &interruptible, "Outbid by system: Fri Jul 12 2024 15:14:40 GMT-0400 (Eastern Daylight Time)", "", true},
+		{"interrupted", "EXITED", &interruptible, "Interrupted: host maintenance", "", true},
+		{"stopped by system", "EXITED", &interruptible, "Stopped by System: Fri Jul 12 2024 15:14:40 GMT-0400", "", true},
+		{"exited by system", "EXITED", &interruptible, "  exited by system: Fri Jul 12 2024", "", true},
+		{"stopped by user", "EXITED", &interruptible, "Stopped by User: Fri Jul 12 2024 15:14:40 GMT-0400 (Eastern Daylight Time)", "", false},
+		{"exited by user", "EXITED", &interruptible, "Exited by user: Fri Jul 12 2024", "", false},
+		{"rented by user", "EXITED", &interruptible, "Rented by User: Fri Jul 12 2024 15:14:40 GMT-0400 (Eastern Daylight Time)", "", false},
+		{"unknown wording", "EXITED", &interruptible, "Halted on request: Fri Jul 12 2024", "", false},
+		{"empty", "EXITED", &interruptible, "", "", false},
+		{"stopped by terraform", "EXITED", &interruptible, "Stopped by system: Fri Jul 12 2024", "Stopped by system: Fri Jul 12 2024", false},
+		{"running", "RUNNING", &interruptible, "Outbid by system: Fri Jul 12 2024", "", false},
+		{"on demand", "EXITED", &onDemand, "Outbid by system: Fri Jul 12 2024", "", false},
+		{"interruptible unknown", "EXITED", nil, "Outbid by system: Fri Jul 12 2024", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &Pod{
+				DesiredStatus:    tt.desiredStatus,
+				Interruptible:    tt.interruptible,
+				LastStatusChange: tt.lastStatusChange,
+			}
+			if got := podPreempted(pod, tt.stopped); got != tt.want {
+				t.Errorf("podPreempted(%q) = %t, want %t", tt.lastStatusChange, got, tt.want)
+			}
+		})
+	}
+}
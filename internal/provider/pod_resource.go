@@ -2,17 +2,28 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -20,6 +31,17 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &PodResource{}
 var _ resource.ResourceWithImportState = &PodResource{}
+var _ resource.ResourceWithIdentity = &PodResource{}
+var _ resource.ResourceWithModifyPlan = &PodResource{}
+var _ resource.ResourceWithValidateConfig = &PodResource{}
+
+// podImportedKey is the private state key marking a Pod imported but not yet
+// read.
+const podImportedKey = "imported"
+
+// defaultPodDeleteTimeout bounds how long Delete waits for a terminated Pod
+// to disappear from the API.
+const defaultPodDeleteTimeout = 5 * time.Minute
 
 func NewPodResource() resource.Resource {
 	return &PodResource{}
@@ -28,6 +50,7 @@ func NewPodResource() resource.Resource {
 // PodResource defines the resource implementation.
 type PodResource struct {
 	client *Client
+	config *providerConfig
 }
 
 // PodResourceModel describes the resource data model.
@@ -47,6 +70,8 @@ type PodResourceModel struct {
 	VolumeMountPath         types.String  `tfsdk:"volume_mount_path"`
 	Ports                   types.List    `tfsdk:"ports"`
 	Env                     types.Map     `tfsdk:"env"`
+	SecretEnv               types.Map     `tfsdk:"secret_env"`
+	SecretEnvVersion        types.Int64   `tfsdk:"secret_env_version"`
 	DockerEntrypoint        types.List    `tfsdk:"docker_entrypoint"`
 	DockerStartCmd          types.List    `tfsdk:"docker_start_cmd"`
 	TemplateId              types.String  `tfsdk:"template_id"`
@@ -60,12 +85,20 @@ type PodResourceModel struct {
 	MinDiskBandwidthMBps    types.Float64 `tfsdk:"min_disk_bandwidth_mbps"`
 	SupportPublicIp         types.Bool    `tfsdk:"support_public_ip"`
 	GlobalNetworking        types.Bool    `tfsdk:"global_networking"`
-	AllowedCudaVersions     types.List    `tfsdk:"allowed_cuda_versions"`
-	CountryCodes            types.List    `tfsdk:"country_codes"`
+	AllowedCudaVersions     types.Set     `tfsdk:"allowed_cuda_versions"`
+	CountryCodes            types.Set     `tfsdk:"country_codes"`
 	GPUTypePriority         types.String  `tfsdk:"gpu_type_priority"`
 	CPUFlavorPriority       types.String  `tfsdk:"cpu_flavor_priority"`
 	DataCenterPriority      types.String  `tfsdk:"data_center_priority"`
 	ContainerRegistryAuthId types.String  `tfsdk:"container_registry_auth_id"`
+	DeletionProtection      types.Bool    `tfsdk:"deletion_protection"`
+	MaxCostPerHr            types.Float64 `tfsdk:"max_cost_per_hr"`
+	TTL                     types.String  `tfsdk:"ttl"`
+	OnPreemption            types.String  `tfsdk:"on_preemption"`
+	SSHPublicKeys           types.List    `tfsdk:"ssh_public_keys"`
+	ExposeSSH               types.Bool    `tfsdk:"expose_ssh"`
+	Fallback                types.List    `tfsdk:"fallback"`
+	CapacityWait            types.Object  `tfsdk:"capacity_wait"`
 	// Computed fields
 	DesiredStatus     types.String  `tfsdk:"desired_status"`
 	PublicIp          types.String  `tfsdk:"public_ip"`
@@ -75,6 +108,16 @@ type PodResourceModel struct {
 	AdjustedCostPerHr types.Float64 `tfsdk:"adjusted_cost_per_hr"`
 	MemoryInGb        types.Float64 `tfsdk:"memory_in_gb"`
 	LastStartedAt     types.String  `tfsdk:"last_started_at"`
+
+	EstimatedCostPerHr types.Float64 `tfsdk:"estimated_cost_per_hr"`
+	ExpiresAt          types.String  `tfsdk:"expires_at"`
+	SelectedSpec       types.Object  `tfsdk:"selected_spec"`
+	Preempted          types.Bool    `tfsdk:"preempted"`
+	PortMappings       types.Map     `tfsdk:"port_mappings"`
+	HTTPURLs           types.Map     `tfsdk:"http_urls"`
+	SSHCommand         types.String  `tfsdk:"ssh_command"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *PodResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -112,24 +155,36 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("GPU"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"cloud_type": schema.StringAttribute{
 				MarkdownDescription: "Set to SECURE to create the Pod in Secure Cloud. Set to COMMUNITY to create the Pod in Community Cloud.",
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("SECURE"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"gpu_count": schema.Int64Attribute{
 				MarkdownDescription: "If the Pod is a GPU Pod, the number of GPUs attached to the Pod.",
 				Optional:            true,
 				Computed:            true,
 				Default:             int64default.StaticInt64(1),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"vcpu_count": schema.Int64Attribute{
 				MarkdownDescription: "If the Pod is a CPU Pod, the number of vCPUs allocated to the Pod.",
 				Optional:            true,
 				Computed:            true,
 				Default:             int64default.StaticInt64(2),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"gpu_type_ids": schema.ListAttribute{
 				MarkdownDescription: "If the Pod is a GPU Pod, a list of RunPod GPU types which can be attached to the Pod.",
@@ -168,30 +223,64 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				MarkdownDescription: "A list of ports exposed on the Pod. Each port is formatted as [port number]/[protocol].",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"env": schema.MapAttribute{
-				MarkdownDescription: "Environment variables for the Pod.",
+				MarkdownDescription: "Environment variables for the Pod. Values may reference RunPod Secrets, e.g. `runpod_secret.example.reference`; referenced Secrets must exist or be managed in the same configuration.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_env": schema.MapAttribute{
+				MarkdownDescription: "Environment variables for the Pod that hold secrets, such as API tokens. Write-only: values are never stored in plan or state, shown in `env` or refreshed from the API; only a hash is kept in private state to detect changes. Takes precedence over `env`. Requires Terraform 1.11 or later.",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"secret_env_version": schema.Int64Attribute{
+				MarkdownDescription: "Incremented each time a change to `secret_env` is applied. `secret_env` is write-only, so this is what shows a change to it in plans.",
+				Computed:            true,
 			},
 			"docker_entrypoint": schema.ListAttribute{
 				MarkdownDescription: "If specified, overrides the ENTRYPOINT for the Docker image run on the Pod.",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"docker_start_cmd": schema.ListAttribute{
 				MarkdownDescription: "If specified, overrides the start CMD for the Docker image run on the Pod.",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"template_id": schema.StringAttribute{
 				MarkdownDescription: "If the Pod is created with a template, the unique string identifying that template.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"network_volume_id": schema.StringAttribute{
 				MarkdownDescription: "The unique string identifying the network volume to attach to the Pod.",
 				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -200,6 +289,9 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
 			},
 			"locked": schema.BoolAttribute{
 				MarkdownDescription: "Set to true to lock a Pod. Locking a Pod disables stopping or resetting the Pod.",
@@ -241,13 +333,13 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
-			"allowed_cuda_versions": schema.ListAttribute{
-				MarkdownDescription: "If the Pod is a GPU Pod, a list of acceptable CUDA versions on the Pod.",
+			"allowed_cuda_versions": schema.SetAttribute{
+				MarkdownDescription: "If the Pod is a GPU Pod, a list of acceptable CUDA versions on the Pod. The API does not report the CUDA version of a Pod, so this is never refreshed.",
 				ElementType:         types.StringType,
 				Optional:            true,
 			},
-			"country_codes": schema.ListAttribute{
-				MarkdownDescription: "A list of country codes where the Pod can be located.",
+			"country_codes": schema.SetAttribute{
+				MarkdownDescription: "A list of country codes where the Pod can be located. The API does not report the country of a Pod, so this is never refreshed.",
 				ElementType:         types.StringType,
 				Optional:            true,
 			},
@@ -272,6 +364,45 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 			"container_registry_auth_id": schema.StringAttribute{
 				MarkdownDescription: "Registry credentials ID.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether Terraform is prevented from deleting the Pod, including when it would be replaced. Defaults to the provider's `deletion_protection` setting.",
+				Optional:            true,
+			},
+			"max_cost_per_hr": schema.Float64Attribute{
+				MarkdownDescription: "Maximum cost of the Pod in USD per hour. If the cost reported after creation, including discounts, exceeds it, the Pod is deleted again and the apply fails. Only checked when the Pod is created.",
+				Optional:            true,
+			},
+			"ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the Pod may run after it is created, as a duration such as `8h` or `72h`. Once it has passed, plans stop the Pod. The expiry is also recorded on the Pod in the `" + podExpiryEnvVar + "` environment variable, which `runpod_expired_pods` reads. Changing `ttl` does not restart the Pod, so a changed expiry is only written to the Pod the next time it is updated.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"ssh_public_keys": schema.ListAttribute{
+				MarkdownDescription: "SSH public keys authorized on the Pod, in `authorized_keys` format. They are passed to the Pod in the `" + sshPublicKeyEnvVar + "` environment variable read by RunPod images, and are not shown in `env`. Conflicts with `PUBLIC_KEY` in `env`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"expose_ssh": schema.BoolAttribute{
+				MarkdownDescription: "Set to true to expose port `" + sshPort + "` on the Pod in addition to `ports`. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"on_preemption": schema.StringAttribute{
+				MarkdownDescription: "What the next apply does when RunPod has stopped an interruptible Pod: `ignore` leaves it stopped, `restart` starts it again and `recreate` replaces it. Defaults to `ignore`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(onPreemptionIgnore),
+				Validators: []validator.String{
+					oneOfValidator{values: []string{onPreemptionIgnore, onPreemptionRestart, onPreemptionRecreate}},
+				},
 			},
 			// Computed fields
 			"desired_status": schema.StringAttribute{
@@ -306,27 +437,151 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				MarkdownDescription: "The UTC timestamp when the Pod was last started.",
 				Computed:            true,
 			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "The UTC timestamp after which the Pod is stopped, computed from `ttl` and the time the Pod was created.",
+				Computed:            true,
+			},
+			"estimated_cost_per_hr": schema.Float64Attribute{
+				MarkdownDescription: "The estimated cost of the Pod in USD per hour, computed at plan time from GPU pricing, `gpu_count`, `cloud_type`, `interruptible`, `container_disk_in_gb` and `volume_in_gb`. When several `gpu_type_ids` are listed the most expensive one is assumed. Null for CPU Pods.",
+				Computed:            true,
+			},
+			"port_mappings": schema.MapAttribute{
+				MarkdownDescription: "The public port each exposed TCP port of the Pod is reachable on, keyed by the internal port.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+			"http_urls": schema.MapAttribute{
+				MarkdownDescription: "The RunPod proxy URL of each HTTP port of the Pod, keyed by the internal port, e.g. `https://{pod id}-8888.proxy.runpod.net`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"ssh_command": schema.StringAttribute{
+				MarkdownDescription: "The command to connect to the Pod over SSH, when port `22/tcp` is exposed on a public IP.",
+				Computed:            true,
+			},
+			"preempted": schema.BoolAttribute{
+				MarkdownDescription: "Whether RunPod has stopped the interruptible Pod, as opposed to Terraform or a user stopping it. Only stops the API describes as an outbid or interruption by RunPod count.",
+				Computed:            true,
+			},
+			"selected_spec": schema.SingleNestedAttribute{
+				MarkdownDescription: "The GPU spec the Pod was created with: the primary spec, or the first `fallback` block that had capacity. Null for imported Pods.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"fallback_index": schema.Int64Attribute{
+						MarkdownDescription: "The index of the `fallback` block used, or null when the primary spec was used.",
+						Computed:            true,
+					},
+					"gpu_type_ids": schema.ListAttribute{
+						MarkdownDescription: "The GPU types requested.",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					"gpu_count": schema.Int64Attribute{
+						MarkdownDescription: "The number of GPUs requested.",
+						Computed:            true,
+					},
+					"cloud_type": schema.StringAttribute{
+						MarkdownDescription: "The cloud type requested.",
+						Computed:            true,
+					},
+					"data_center_ids": schema.ListAttribute{
+						MarkdownDescription: "The data centers requested.",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Delete: true,
+			}),
+			"capacity_wait": schema.SingleNestedBlock{
+				MarkdownDescription: "Keep retrying Pod creation while the RunPod API reports that no machine has capacity for the primary spec or any `fallback`, instead of failing the apply. Only used when the Pod is created.",
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.StringAttribute{
+						MarkdownDescription: "How long to wait for capacity, as a duration such as `30m` or `6h`.",
+						Required:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+					"interval": schema.StringAttribute{
+						MarkdownDescription: "How long to wait between attempts, as a duration such as `30s`. Defaults to `30s`.",
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+				},
+			},
+			"fallback": schema.ListNestedBlock{
+				MarkdownDescription: "Alternative GPU specs, tried in order when the RunPod API reports that no machine has capacity for the primary spec. Attributes that are not set are taken from the primary spec. Only used when the Pod is created.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"gpu_type_ids": schema.ListAttribute{
+							MarkdownDescription: "A list of RunPod GPU types which can be attached to the Pod.",
+							ElementType:         types.StringType,
+							Required:            true,
+						},
+						"gpu_count": schema.Int64Attribute{
+							MarkdownDescription: "The number of GPUs attached to the Pod.",
+							Optional:            true,
+						},
+						"cloud_type": schema.StringAttribute{
+							MarkdownDescription: "Set to SECURE to create the Pod in Secure Cloud. Set to COMMUNITY to create the Pod in Community Cloud.",
+							Optional:            true,
+						},
+						"data_center_ids": schema.ListAttribute{
+							MarkdownDescription: "A list of RunPod data center IDs where the Pod can be located.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+func (r *PodResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema("Pod", resp)
+}
+
+func (r *PodResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var env types.Map
+	var keys types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("env"), &env)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ssh_public_keys"), &keys)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateSSHKeysEnv(env, keys)...)
+}
+
 func (r *PodResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*Client)
+	config, ok := req.ProviderData.(*providerConfig)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = config.client
+	r.config = config
 }
 
 func (r *PodResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -342,7 +597,7 @@ func (r *PodResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	// Build create input
 	input := &PodCreateInput{
-		Name:                    data.Name.ValueString(),
+		Name:                    r.config.prefixedName(data.Name.ValueString()),
 		ImageName:               data.ImageName.ValueString(),
 		ComputeType:             data.ComputeType.ValueString(),
 		CloudType:               data.CloudType.ValueString(),
@@ -423,13 +678,13 @@ func (r *PodResource) Create(ctx context.Context, req resource.CreateRequest, re
 	if !data.DataCenterIds.IsNull() {
 		resp.Diagnostics.Append(data.DataCenterIds.ElementsAs(ctx, &input.DataCenterIds, false)...)
 	}
-	if !data.Ports.IsNull() {
+	if !data.Ports.IsNull() && !data.Ports.IsUnknown() {
 		resp.Diagnostics.Append(data.Ports.ElementsAs(ctx, &input.Ports, false)...)
 	}
-	if !data.DockerEntrypoint.IsNull() {
+	if !data.DockerEntrypoint.IsNull() && !data.DockerEntrypoint.IsUnknown() {
 		resp.Diagnostics.Append(data.DockerEntrypoint.ElementsAs(ctx, &input.DockerEntrypoint, false)...)
 	}
-	if !data.DockerStartCmd.IsNull() {
+	if !data.DockerStartCmd.IsNull() && !data.DockerStartCmd.IsUnknown() {
 		resp.Diagnostics.Append(data.DockerStartCmd.ElementsAs(ctx, &input.DockerStartCmd, false)...)
 	}
 	if !data.AllowedCudaVersions.IsNull() {
@@ -440,26 +695,66 @@ func (r *PodResource) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 
 	// Handle map
-	if !data.Env.IsNull() {
+	if !data.Env.IsNull() && !data.Env.IsUnknown() {
 		resp.Diagnostics.Append(data.Env.ElementsAs(ctx, &input.Env, false)...)
 	}
+	input.Env = r.config.mergedEnv(input.Env)
+
+	var diags diag.Diagnostics
+	input.Env, diags = sshKeysEnv(ctx, input.Env, data.SSHPublicKeys)
+	resp.Diagnostics.Append(diags...)
+	secrets, diags := configSecretEnv(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	input.Env = withSecretEnv(input.Env, secrets)
+	input.Ports = exposeSSHPort(input.Ports, data.ExposeSSH)
+
+	createdAt := time.Now().UTC()
+	data.ExpiresAt = types.StringNull()
+	if !data.TTL.IsNull() {
+		ttl, err := time.ParseDuration(data.TTL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid Duration", err.Error())
+			return
+		}
+		data.ExpiresAt = types.StringValue(createdAt.Add(ttl).Format(time.RFC3339))
+	}
+	input.Env = expiryEnv(input.Env, data.ExpiresAt)
+
+	specs, diags := podCreateSpecs(ctx, &data, input)
+	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	pod, err := r.client.CreatePod(ctx, input)
+	pod, spec, err := r.createPodWaitingForCapacity(ctx, &data, specs)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create pod, got error: %s", err))
 		return
 	}
 
-	tflog.Trace(ctx, "Created Pod", map[string]interface{}{"id": pod.ID})
+	tflog.Trace(ctx, "Created Pod", map[string]interface{}{"id": pod.ID, "spec": specDescription(spec)})
+
+	data.SelectedSpec, diags = selectedSpecValue(ctx, spec)
+	resp.Diagnostics.Append(diags...)
+	data.Preempted = types.BoolValue(false)
+
+	resp.Diagnostics.Append(r.enforceCostLimits(ctx, &data, pod)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createdAtJSON, _ := json.Marshal(createdAt)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, podCreatedAtKey, createdAtJSON)...)
+	resp.Diagnostics.Append(recordSecretEnv(ctx, resp.Private, secrets)...)
+	resp.Diagnostics.Append(recordExpiry(ctx, resp.Private, data.ExpiresAt)...)
+	data.SecretEnvVersion = types.Int64Value(0)
 
 	// Update state with response
-	r.updateStateFromPod(ctx, &data, pod)
+	resp.Diagnostics.Append(r.updateStateFromPod(ctx, &data, pod, secretEnvNames(secrets))...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *PodResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -479,15 +774,31 @@ func (r *PodResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	r.updateStateFromPod(ctx, &data, pod)
+	secretNames, diags := recordedSecretEnvNames(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(checkSecretEnv(ctx, req.Private, pod.Env, "pod "+pod.ID)...)
+	resp.Diagnostics.Append(r.updateStateFromPod(ctx, &data, pod, secretNames)...)
+	data.SecretEnvVersion = refreshSecretEnvVersion(data.SecretEnvVersion)
+	resp.Diagnostics.Append(refreshRecordedExpiry(ctx, req.Private, &data)...)
+
+	// The first read after import adopts the placement of the Pod.
+	imported, diags := req.Private.GetKey(ctx, podImportedKey)
+	resp.Diagnostics.Append(diags...)
+	if len(imported) > 0 {
+		resp.Diagnostics.Append(adoptPodPlacement(ctx, &data, pod)...)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, podImportedKey, nil)...)
+	}
+	resp.Diagnostics.Append(r.refreshPreempted(ctx, &data, pod, req.Private)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *PodResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data PodResourceModel
+	var data, state PodResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -495,9 +806,105 @@ func (r *PodResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	tflog.Debug(ctx, "Updating Pod", map[string]interface{}{"id": data.ID.ValueString()})
 
-	// Build update input
+	// An expiry that could not be planned counts from now.
+	if data.ExpiresAt.IsUnknown() {
+		ttl, err := time.ParseDuration(data.TTL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid Duration", err.Error())
+			return
+		}
+		data.ExpiresAt = types.StringValue(time.Now().UTC().Add(ttl).Format(time.RFC3339))
+	}
+
+	input, diags := r.podUpdateInput(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	prior, diags := r.podUpdateInput(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	secrets, diags := configSecretEnv(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	secretsChanged, diags := secretEnvChanged(ctx, req.Private, secrets)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Updating a Pod resets it, so skip the update when only computed
+	// attributes such as desired_status, or the expiry, changed. A changed
+	// expiry is only written to the Pod along with other changes.
+	var pod *Pod
+	var err error
+	if reflect.DeepEqual(input, prior) && !secretsChanged {
+		pod, err = r.client.GetPod(ctx, data.ID.ValueString())
+	} else {
+		input.Env = expiryEnv(withSecretEnv(input.Env, secrets), data.ExpiresAt)
+		pod, err = r.client.UpdatePod(ctx, data.ID.ValueString(), input)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update pod, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Updated Pod", map[string]interface{}{"id": pod.ID})
+
+	resp.Diagnostics.Append(recordSecretEnv(ctx, resp.Private, secrets)...)
+	resp.Diagnostics.Append(recordExpiry(ctx, resp.Private, data.ExpiresAt)...)
+	data.SecretEnvVersion = appliedSecretEnvVersion(data.SecretEnvVersion, state.SecretEnvVersion, secretsChanged)
+	expiresAt := data.ExpiresAt
+
+	// Stop the Pod when its expiry has passed.
+	stop := data.DesiredStatus.ValueString() == "EXITED" && state.DesiredStatus.ValueString() != "EXITED"
+	if stop {
+		tflog.Debug(ctx, "Stopping expired Pod", map[string]interface{}{"id": pod.ID})
+
+		if err := r.client.StopPod(ctx, pod.ID); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to stop expired pod, got error: %s", err))
+			return
+		}
+
+		// Remember the stop so that it is not mistaken for a preemption.
+		if stopped, err := r.client.GetPod(ctx, pod.ID); err == nil {
+			pod = stopped
+			stoppedJSON, _ := json.Marshal(stopped.LastStatusChange)
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, podStoppedStatusChangeKey, stoppedJSON)...)
+		}
+	}
+
+	// Start a preempted Pod again when on_preemption is restart.
+	start := data.DesiredStatus.ValueString() == "RUNNING" && state.DesiredStatus.ValueString() == "EXITED"
+	if start {
+		tflog.Debug(ctx, "Starting preempted Pod", map[string]interface{}{"id": pod.ID})
+
+		if err := r.client.StartPod(ctx, pod.ID); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to start preempted pod, got error: %s", err))
+			return
+		}
+	}
+
+	// Update state with response
+	resp.Diagnostics.Append(r.updateStateFromPod(ctx, &data, pod, secretEnvNames(secrets))...)
+	resp.Diagnostics.Append(r.refreshPreempted(ctx, &data, pod, resp.Private)...)
+	data.ExpiresAt = expiresAt
+
+	if stop {
+		data.DesiredStatus = types.StringValue("EXITED")
+	}
+	if start {
+		data.DesiredStatus = types.StringValue("RUNNING")
+		data.Preempted = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
+}
+
+// podUpdateInput builds the update request for a Pod, without secret_env,
+// which is not part of the plan or state.
+func (r *PodResource) podUpdateInput(ctx context.Context, data *PodResourceModel) (*PodUpdateInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	input := &PodUpdateInput{
-		Name:                    data.Name.ValueString(),
+		Name:                    r.config.prefixedName(data.Name.ValueString()),
 		ImageName:               data.ImageName.ValueString(),
 		VolumeMountPath:         data.VolumeMountPath.ValueString(),
 		ContainerRegistryAuthId: data.ContainerRegistryAuthId.ValueString(),
@@ -524,37 +931,26 @@ func (r *PodResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	}
 
 	// Handle string lists
-	if !data.Ports.IsNull() {
-		resp.Diagnostics.Append(data.Ports.ElementsAs(ctx, &input.Ports, false)...)
+	if !data.Ports.IsNull() && !data.Ports.IsUnknown() {
+		diags.Append(data.Ports.ElementsAs(ctx, &input.Ports, false)...)
 	}
-	if !data.DockerEntrypoint.IsNull() {
-		resp.Diagnostics.Append(data.DockerEntrypoint.ElementsAs(ctx, &input.DockerEntrypoint, false)...)
+	if !data.DockerEntrypoint.IsNull() && !data.DockerEntrypoint.IsUnknown() {
+		diags.Append(data.DockerEntrypoint.ElementsAs(ctx, &input.DockerEntrypoint, false)...)
 	}
-	if !data.DockerStartCmd.IsNull() {
-		resp.Diagnostics.Append(data.DockerStartCmd.ElementsAs(ctx, &input.DockerStartCmd, false)...)
+	if !data.DockerStartCmd.IsNull() && !data.DockerStartCmd.IsUnknown() {
+		diags.Append(data.DockerStartCmd.ElementsAs(ctx, &input.DockerStartCmd, false)...)
 	}
 
 	// Handle map
-	if !data.Env.IsNull() {
-		resp.Diagnostics.Append(data.Env.ElementsAs(ctx, &input.Env, false)...)
-	}
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	pod, err := r.client.UpdatePod(ctx, data.ID.ValueString(), input)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update pod, got error: %s", err))
-		return
+	if !data.Env.IsNull() && !data.Env.IsUnknown() {
+		diags.Append(data.Env.ElementsAs(ctx, &input.Env, false)...)
 	}
+	env, d := sshKeysEnv(ctx, r.config.mergedEnv(input.Env), data.SSHPublicKeys)
+	diags.Append(d...)
+	input.Env = env
+	input.Ports = exposeSSHPort(input.Ports, data.ExposeSSH)
 
-	tflog.Trace(ctx, "Updated Pod", map[string]interface{}{"id": pod.ID})
-
-	// Update state with response
-	r.updateStateFromPod(ctx, &data, pod)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return input, diags
 }
 
 func (r *PodResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -566,8 +962,23 @@ func (r *PodResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	if r.config.deletionProtected(data.DeletionProtection) {
+		resp.Diagnostics.AddError(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("Pod %s has deletion protection enabled. "+
+				"Set deletion_protection = false on the resource, or on the provider if the resource does not set it, and apply before deleting it.", data.ID.ValueString()),
+		)
+		return
+	}
+
 	tflog.Debug(ctx, "Deleting Pod", map[string]interface{}{"id": data.ID.ValueString()})
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultPodDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeletePod(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete pod, got error: %s", err))
@@ -575,7 +986,7 @@ func (r *PodResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 
 	// Wait for pod to be deleted (with timeout)
-	timeout := time.After(5 * time.Minute)
+	timeout := time.After(deleteTimeout)
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
@@ -595,39 +1006,417 @@ func (r *PodResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 }
 
+// enforceCostLimits deletes a newly created Pod again when it costs more than
+// max_cost_per_hr, or would take the Pods created during this run over the
+// provider's max_total_cost_per_hr.
+func (r *PodResource) enforceCostLimits(ctx context.Context, data *PodResourceModel, pod *Pod) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.MaxCostPerHr.IsNull() && r.config.maxTotalCostPerHr == nil {
+		return diags
+	}
+
+	// The create response may omit costs, in which case they are read back.
+	cost := pod.AdjustedCostPerHr
+	if cost == nil {
+		cost = pod.CostPerHr
+	}
+	if cost == nil {
+		current, err := r.client.GetPod(ctx, pod.ID)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read cost of pod %s, got error: %s", pod.ID, err))
+			return r.deleteOverBudgetPod(ctx, pod.ID, diags)
+		}
+		cost = current.AdjustedCostPerHr
+		if cost == nil {
+			cost = current.CostPerHr
+		}
+	}
+	if cost == nil {
+		diags.AddError(
+			"Unknown Pod Cost",
+			fmt.Sprintf("The RunPod API did not report the cost of pod %s, so the configured cost limits cannot be checked.", pod.ID),
+		)
+		return r.deleteOverBudgetPod(ctx, pod.ID, diags)
+	}
+
+	if !data.MaxCostPerHr.IsNull() && *cost > data.MaxCostPerHr.ValueFloat64() {
+		diags.AddAttributeError(
+			path.Root("max_cost_per_hr"),
+			"Pod Cost Limit Exceeded",
+			fmt.Sprintf("Pod %s costs $%.3f per hour, more than max_cost_per_hr of $%.3f. The pod has been deleted.",
+				pod.ID, *cost, data.MaxCostPerHr.ValueFloat64()),
+		)
+		return r.deleteOverBudgetPod(ctx, pod.ID, diags)
+	}
+
+	if total, ok := r.config.reserveCost(*cost); !ok {
+		diags.AddError(
+			"Provider Cost Limit Exceeded",
+			fmt.Sprintf("Pod %s costs $%.3f per hour, which would bring the pods created in this run to $%.3f per hour, "+
+				"more than the provider's max_total_cost_per_hr of $%.3f. The pod has been deleted.",
+				pod.ID, *cost, total, *r.config.maxTotalCostPerHr),
+		)
+		return r.deleteOverBudgetPod(ctx, pod.ID, diags)
+	}
+
+	tflog.Debug(ctx, "Pod within cost limits", map[string]interface{}{"id": pod.ID, "cost_per_hr": *cost})
+
+	return diags
+}
+
+// deleteOverBudgetPod deletes a Pod that failed a cost check, so that it is
+// not left running outside of Terraform state.
+func (r *PodResource) deleteOverBudgetPod(ctx context.Context, id string, diags diag.Diagnostics) diag.Diagnostics {
+	if err := r.client.DeletePod(ctx, id); err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete pod %s after a failed cost check, got error: %s. Delete the pod manually to stop being charged for it.", id, err),
+		)
+	}
+	return diags
+}
+
+// ModifyPlan plans the expiry of the Pod, checks the secrets referenced in
+// env and computes estimated_cost_per_hr from the cached GPU pricing catalog,
+// so that plans show what a change will cost.
+func (r *PodResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to estimate when destroying, or before the provider is
+	// configured.
+	if req.Plan.Raw.IsNull() || r.config == nil {
+		return
+	}
+
+	planSecretEnvVersion(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.planExpiry(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.planPreemption(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan PodResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.config.validateSecretReferences(ctx, plan.Env, path.Root("env"))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	catalog, err := r.config.gpuCatalog(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Estimate Cost",
+			fmt.Sprintf("Unable to read GPU pricing, got error: %s. estimated_cost_per_hr will be known after apply.", err),
+		)
+		return
+	}
+
+	estimate, diags := estimatePodCostPerHr(ctx, catalog, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	// Keep the recorded estimate while the inputs it depends on are
+	// unchanged, so that price changes alone never produce a diff.
+	if !req.State.Raw.IsNull() {
+		var state PodResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		current, diags := estimatePodCostPerHr(ctx, catalog, &state)
+		resp.Diagnostics.Append(diags...)
+
+		if current.Equal(estimate) && !state.EstimatedCostPerHr.IsNull() {
+			estimate = state.EstimatedCostPerHr
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("estimated_cost_per_hr"), estimate)...)
+}
+
+// refreshEstimatedCost fills in estimated_cost_per_hr when planning could not
+// compute it, for example after import. A recorded estimate is kept.
+func (r *PodResource) refreshEstimatedCost(ctx context.Context, data *PodResourceModel) diag.Diagnostics {
+	if !data.EstimatedCostPerHr.IsNull() && !data.EstimatedCostPerHr.IsUnknown() {
+		return nil
+	}
+
+	data.EstimatedCostPerHr = types.Float64Null()
+
+	catalog, err := r.config.gpuCatalog(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to read GPU pricing", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+
+	estimate, diags := estimatePodCostPerHr(ctx, catalog, data)
+	if !estimate.IsUnknown() {
+		data.EstimatedCostPerHr = estimate
+	}
+	return diags
+}
+
+// ImportState imports a Pod by ID or identity, or by name given as
+// name:<name>.
 func (r *PodResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, podImportedKey, []byte("true"))...)
+
+	name, ok := importName(req.ID)
+	if !ok {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		return
+	}
+
+	pods, err := r.client.ListPods(ctx, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list pods, got error: %s", err))
+		return
+	}
+
+	candidates := make([]importCandidate, 0, len(pods))
+	for _, pod := range pods {
+		candidates = append(candidates, importCandidate{id: pod.ID, name: pod.Name})
+	}
+
+	id, diags := resolveImportName("Pod", r.config.prefixedName(name), candidates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
-// updateStateFromPod updates the Terraform state from a Pod API response
-func (r *PodResource) updateStateFromPod(ctx context.Context, data *PodResourceModel, pod *Pod) {
+// updateStateFromPod updates the Terraform state from a Pod API response.
+//
+// Every attribute the API reports is refreshed so that changes made outside
+// of Terraform show up in plans. The GPU type, CPU flavor and data center
+// lists are refreshed from where the Pod was placed. Other placement filters,
+// priorities and networking options are only used when the Pod is created
+// and are never reported back; they keep their prior value, or their schema
+// default when there is none, e.g. after import. The secret_env variables
+// named by secretNames are left out of env.
+func (r *PodResource) updateStateFromPod(ctx context.Context, data *PodResourceModel, pod *Pod, secretNames []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
 	data.ID = types.StringValue(pod.ID)
-	data.Name = types.StringValue(pod.Name)
+	data.Name = refreshString(data.Name, r.config.unprefixedName(pod.Name))
+	data.ImageName = refreshString(data.ImageName, pod.Image)
+	data.TemplateId = refreshString(data.TemplateId, pod.TemplateId)
+	data.ContainerRegistryAuthId = refreshString(data.ContainerRegistryAuthId, pod.ContainerRegistryAuthId)
+	data.VolumeMountPath = refreshString(data.VolumeMountPath, pod.VolumeMountPath)
+	data.ContainerDiskInGb = refreshInt64(data.ContainerDiskInGb, pod.ContainerDiskInGb)
+	data.VolumeInGb = refreshInt64(data.VolumeInGb, pod.VolumeInGb)
+	data.Interruptible = refreshBool(data.Interruptible, pod.Interruptible)
+	data.Locked = refreshBool(data.Locked, pod.Locked)
+
+	if expiry, ok := podExpiry(pod); ok {
+		data.ExpiresAt = types.StringValue(expiry.UTC().Format(time.RFC3339))
+	} else {
+		data.ExpiresAt = types.StringNull()
+	}
+
+	env := withoutSecretEnv(r.config.userEnv(data.Env, pod.Env), secretNames)
+	delete(env, podExpiryEnvVar)
+	data.SSHPublicKeys, env, d = refreshSSHKeys(ctx, data.SSHPublicKeys, env)
+	diags.Append(d...)
+	data.Env, d = refreshStringMap(ctx, data.Env, env)
+	diags.Append(d...)
+	data.Ports, d = refreshStringList(ctx, data.Ports, unexposeSSHPort(ctx, data.Ports, pod.Ports, data.ExposeSSH))
+	diags.Append(d...)
+	data.DockerEntrypoint, d = refreshStringList(ctx, data.DockerEntrypoint, pod.DockerEntrypoint)
+	diags.Append(d...)
+	data.DockerStartCmd, d = refreshStringList(ctx, data.DockerStartCmd, pod.DockerStartCmd)
+	diags.Append(d...)
+
+	if pod.NetworkVolume != nil {
+		data.NetworkVolumeId = refreshString(data.NetworkVolumeId, pod.NetworkVolume.ID)
+	} else {
+		data.NetworkVolumeId = refreshString(data.NetworkVolumeId, "")
+	}
+
+	// A Pod is a GPU Pod when the API reports its GPUs, and a CPU Pod when it
+	// reports a CPU flavor. The vCPU count is only configurable for CPU Pods.
+	// A Pod created from a fallback spec keeps the configured GPU count and
+	// cloud type, so that the fallback does not cause a replacement.
+	fallback := usesFallback(data)
+	if !fallback {
+		diags.Append(refreshPodPlacement(ctx, data, pod)...)
+	}
+	if pod.GPU != nil {
+		data.ComputeType = types.StringValue("GPU")
+		if !fallback {
+			data.GPUCount = refreshInt64(data.GPUCount, pod.GPU.Count)
+		}
+	} else if pod.CPUFlavorId != "" {
+		data.ComputeType = types.StringValue("CPU")
+		if pod.VCPUCount != nil {
+			data.VCPUCount = types.Int64Value(int64(*pod.VCPUCount))
+		}
+	}
+
+	actualDataCenter := ""
+	if pod.Machine != nil {
+		actualDataCenter = pod.Machine.DataCenterId
+		if pod.Machine.SecureCloud != nil && !fallback {
+			if *pod.Machine.SecureCloud {
+				data.CloudType = types.StringValue("SECURE")
+			} else {
+				data.CloudType = types.StringValue("COMMUNITY")
+			}
+		}
+	}
+	setPodDefaults(data)
 
-	// Only update ImageName if API returns a non-empty value
-	// RunPod API doesn't return image name in GET responses, so preserve the planned value
-	if pod.ImageName != "" {
-		data.ImageName = types.StringValue(pod.ImageName)
+	if data.SelectedSpec.IsUnknown() {
+		data.SelectedSpec = types.ObjectNull(podSelectedSpecAttrTypes)
 	}
 
+	diags.Append(r.refreshEstimatedCost(ctx, data)...)
+
+	data.PortMappings, data.HTTPURLs, data.SSHCommand, d = podEndpoints(ctx, pod)
+	diags.Append(d...)
+
 	data.DesiredStatus = types.StringValue(pod.DesiredStatus)
 	data.PublicIp = types.StringValue(pod.PublicIp)
 	data.MachineId = types.StringValue(pod.MachineId)
-	// TODO: Figure out how to get actual data center from RunPod API
-	// For now, we'll leave this empty until we find a way to determine the region
-	data.ActualDataCenter = types.StringValue("")
-	data.CostPerHr = types.Float64Value(pod.CostPerHr)
-	data.AdjustedCostPerHr = types.Float64Value(pod.AdjustedCostPerHr)
-	data.MemoryInGb = types.Float64Value(pod.MemoryInGb)
+	data.ActualDataCenter = types.StringValue(actualDataCenter)
+	data.CostPerHr = types.Float64PointerValue(pod.CostPerHr)
+	data.AdjustedCostPerHr = types.Float64PointerValue(pod.AdjustedCostPerHr)
+	data.MemoryInGb = types.Float64PointerValue(pod.MemoryInGb)
 	data.LastStartedAt = types.StringValue(pod.LastStartedAt)
 
-	if pod.VolumeInGb > 0 {
-		data.VolumeInGb = types.Int64Value(int64(pod.VolumeInGb))
+	return diags
+}
+
+// podPlacement returns the GPU type, CPU flavor and data center the Pod was
+// placed on. The API reports those rather than the lists the Pod was allowed
+// to use.
+func podPlacement(pod *Pod) (gpuTypeId, cpuFlavorId, dataCenterId string) {
+	if pod.GPU != nil {
+		gpuTypeId = pod.GPU.ID
+	}
+	if pod.Machine != nil {
+		dataCenterId = pod.Machine.DataCenterId
+	}
+	return gpuTypeId, pod.CPUFlavorId, dataCenterId
+}
+
+// refreshPodPlacement refreshes gpu_type_ids, cpu_flavor_ids and
+// data_center_ids from the placement of the Pod. Lists without a value stay
+// unset, so that a Pod configured without them plans cleanly.
+func refreshPodPlacement(ctx context.Context, data *PodResourceModel, pod *Pod) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	gpuTypeId, cpuFlavorId, dataCenterId := podPlacement(pod)
+	data.GPUTypeIds, d = refreshPlacementList(ctx, data.GPUTypeIds, gpuTypeId)
+	diags.Append(d...)
+	data.CPUFlavorIds, d = refreshPlacementList(ctx, data.CPUFlavorIds, cpuFlavorId)
+	diags.Append(d...)
+	data.DataCenterIds, d = refreshPlacementList(ctx, data.DataCenterIds, dataCenterId)
+	diags.Append(d...)
+
+	return diags
+}
+
+// adoptPodPlacement sets gpu_type_ids, cpu_flavor_ids and data_center_ids
+// that have no value to the placement of an imported Pod. It is only used on
+// the first read after import, since a Pod configured without them would
+// otherwise never plan cleanly.
+func adoptPodPlacement(ctx context.Context, data *PodResourceModel, pod *Pod) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	gpuTypeId, cpuFlavorId, dataCenterId := podPlacement(pod)
+	if data.GPUTypeIds.IsNull() && gpuTypeId != "" {
+		data.GPUTypeIds, d = types.ListValueFrom(ctx, types.StringType, []string{gpuTypeId})
+		diags.Append(d...)
+	}
+	if data.CPUFlavorIds.IsNull() && cpuFlavorId != "" {
+		data.CPUFlavorIds, d = types.ListValueFrom(ctx, types.StringType, []string{cpuFlavorId})
+		diags.Append(d...)
+	}
+	if data.DataCenterIds.IsNull() && dataCenterId != "" {
+		data.DataCenterIds, d = types.ListValueFrom(ctx, types.StringType, []string{dataCenterId})
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// setPodDefaults fills attributes the API never reports with their schema
+// defaults when they have no value, so that an imported Pod plans cleanly
+// against a configuration that relies on those defaults.
+func setPodDefaults(data *PodResourceModel) {
+	if data.Name.IsNull() {
+		data.Name = types.StringValue("my pod")
+	}
+	if data.ComputeType.IsNull() {
+		data.ComputeType = types.StringValue("GPU")
+	}
+	if data.CloudType.IsNull() {
+		data.CloudType = types.StringValue("SECURE")
+	}
+	if data.GPUCount.IsNull() {
+		data.GPUCount = types.Int64Value(1)
+	}
+	if data.VCPUCount.IsNull() {
+		data.VCPUCount = types.Int64Value(2)
+	}
+	if data.ContainerDiskInGb.IsNull() {
+		data.ContainerDiskInGb = types.Int64Value(50)
+	}
+	if data.VolumeInGb.IsNull() {
+		data.VolumeInGb = types.Int64Value(20)
+	}
+	if data.VolumeMountPath.IsNull() {
+		data.VolumeMountPath = types.StringValue("/workspace")
+	}
+	if data.Interruptible.IsNull() {
+		data.Interruptible = types.BoolValue(false)
+	}
+	if data.Locked.IsNull() {
+		data.Locked = types.BoolValue(false)
+	}
+	if data.MinVCPUPerGPU.IsNull() {
+		data.MinVCPUPerGPU = types.Int64Value(2)
+	}
+	if data.MinRAMPerGPU.IsNull() {
+		data.MinRAMPerGPU = types.Int64Value(8)
+	}
+	if data.GlobalNetworking.IsNull() {
+		data.GlobalNetworking = types.BoolValue(false)
+	}
+	if data.GPUTypePriority.IsNull() {
+		data.GPUTypePriority = types.StringValue("availability")
+	}
+	if data.CPUFlavorPriority.IsNull() {
+		data.CPUFlavorPriority = types.StringValue("availability")
+	}
+	if data.DataCenterPriority.IsNull() {
+		data.DataCenterPriority = types.StringValue("availability")
+	}
+	if data.ExposeSSH.IsNull() {
+		data.ExposeSSH = types.BoolValue(false)
 	}
-	if pod.ContainerDiskInGb > 0 {
-		data.ContainerDiskInGb = types.Int64Value(int64(pod.ContainerDiskInGb))
+	if data.OnPreemption.IsNull() {
+		data.OnPreemption = types.StringValue(onPreemptionIgnore)
 	}
-	if pod.VolumeMountPath != "" {
-		data.VolumeMountPath = types.StringValue(pod.VolumeMountPath)
+	if data.Fallback.IsNull() {
+		data.Fallback = types.ListValueMust(types.ObjectType{AttrTypes: podFallbackAttrTypes}, []attr.Value{})
 	}
 }
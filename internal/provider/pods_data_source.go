@@ -128,25 +128,30 @@ func (d *PodsDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	tflog.Debug(ctx, "Reading Pods data source")
 
-	pods, err := d.client.ListPods(ctx)
+	pods, err := d.client.ListPods(ctx, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list pods, got error: %s", err))
 		return
 	}
 
 	for _, pod := range pods {
+		gpuCount := types.Int64Value(0)
+		if pod.GPU != nil {
+			gpuCount = int64PointerValue(pod.GPU.Count)
+		}
+
 		podData := PodDataModel{
 			ID:                types.StringValue(pod.ID),
 			Name:              types.StringValue(pod.Name),
-			ImageName:         types.StringValue(pod.ImageName),
+			ImageName:         types.StringValue(pod.Image),
 			DesiredStatus:     types.StringValue(pod.DesiredStatus),
 			PublicIp:          types.StringValue(pod.PublicIp),
 			MachineId:         types.StringValue(pod.MachineId),
-			CostPerHr:         types.Float64Value(pod.CostPerHr),
-			AdjustedCostPerHr: types.Float64Value(pod.AdjustedCostPerHr),
-			MemoryInGb:        types.Float64Value(pod.MemoryInGb),
-			VCPUCount:         types.Float64Value(float64(pod.VCPUCount)),
-			GPUCount:          types.Int64Value(int64(pod.GPUCount)),
+			CostPerHr:         types.Float64PointerValue(pod.CostPerHr),
+			AdjustedCostPerHr: types.Float64PointerValue(pod.AdjustedCostPerHr),
+			MemoryInGb:        types.Float64PointerValue(pod.MemoryInGb),
+			VCPUCount:         types.Float64PointerValue(pod.VCPUCount),
+			GPUCount:          gpuCount,
 		}
 		data.Pods = append(data.Pods, podData)
 	}
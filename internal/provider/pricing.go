@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// diskCostPerGbHr is the price of container disk and Pod volume storage,
+// $0.10 per GB per month, per hour.
+const diskCostPerGbHr = 0.10 / hoursPerMonth
+
+// gpuCatalog maps GPU type IDs to their pricing.
+type gpuCatalog map[string]GPUType
+
+// gpuCatalog returns the GPU pricing catalog, fetching it on first use so
+// that a provider run makes at most one request for it.
+func (c *providerConfig) gpuCatalog(ctx context.Context) (gpuCatalog, error) {
+	c.gpuCatalogMu.Lock()
+	defer c.gpuCatalogMu.Unlock()
+
+	if c.gpuCatalogCache != nil {
+		return c.gpuCatalogCache, nil
+	}
+
+	gpuTypes, err := c.client.ListGPUTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := make(gpuCatalog, len(gpuTypes))
+	for _, gpuType := range gpuTypes {
+		catalog[gpuType.ID] = gpuType
+	}
+	c.gpuCatalogCache = catalog
+
+	return catalog, nil
+}
+
+// maxPrice returns the highest hourly price of the listed GPU types in the
+// given cloud, or nil if none of them is offered there. The highest price is
+// used because which of the types is rented depends on availability.
+func (c gpuCatalog) maxPrice(gpuTypeIds []string, secure, spot bool) *float64 {
+	var max *float64
+	for _, id := range gpuTypeIds {
+		gpuType, ok := c[id]
+		if !ok {
+			continue
+		}
+
+		var price *float64
+		switch {
+		case secure && spot:
+			price = gpuType.SecureSpotPrice
+		case secure:
+			price = gpuType.SecurePrice
+		case spot:
+			price = gpuType.CommunitySpotPrice
+		default:
+			price = gpuType.CommunityPrice
+		}
+
+		if price != nil && *price > 0 && (max == nil || *price > *max) {
+			max = price
+		}
+	}
+	return max
+}
+
+// estimatePodCostPerHr estimates the hourly cost of a GPU Pod from its GPUs
+// and disks. It is unknown while any input is unknown, and null for CPU Pods
+// or when no price is known for the GPU types.
+func estimatePodCostPerHr(ctx context.Context, catalog gpuCatalog, data *PodResourceModel) (types.Float64, diag.Diagnostics) {
+	for _, v := range []interface{ IsUnknown() bool }{
+		data.ComputeType, data.GPUTypeIds, data.GPUCount, data.CloudType,
+		data.Interruptible, data.ContainerDiskInGb, data.VolumeInGb,
+	} {
+		if v.IsUnknown() {
+			return types.Float64Unknown(), nil
+		}
+	}
+
+	if data.ComputeType.ValueString() == "CPU" || data.GPUTypeIds.IsNull() {
+		return types.Float64Null(), nil
+	}
+
+	var gpuTypeIds []string
+	diags := data.GPUTypeIds.ElementsAs(ctx, &gpuTypeIds, false)
+	if diags.HasError() {
+		return types.Float64Null(), diags
+	}
+
+	price := catalog.maxPrice(gpuTypeIds, data.CloudType.ValueString() != "COMMUNITY", data.Interruptible.ValueBool())
+	if price == nil {
+		return types.Float64Null(), diags
+	}
+
+	disk := float64(data.ContainerDiskInGb.ValueInt64() + data.VolumeInGb.ValueInt64())
+	cost := *price*float64(data.GPUCount.ValueInt64()) + disk*diskCostPerGbHr
+
+	return types.Float64Value(roundCost(cost)), diags
+}
+
+// estimateEndpointCostPerHr estimates the hourly cost of the always-on
+// workers of a GPU Endpoint, workers_min of them, at secure cloud prices.
+// Workers started to handle load are not included.
+func estimateEndpointCostPerHr(ctx context.Context, catalog gpuCatalog, data *EndpointResourceModel) (types.Float64, diag.Diagnostics) {
+	for _, v := range []interface{ IsUnknown() bool }{
+		data.ComputeType, data.GPUTypeIds, data.GPUCount, data.WorkersMin,
+	} {
+		if v.IsUnknown() {
+			return types.Float64Unknown(), nil
+		}
+	}
+
+	if data.ComputeType.ValueString() == "CPU" || data.GPUTypeIds.IsNull() {
+		return types.Float64Null(), nil
+	}
+
+	var gpuTypeIds []string
+	diags := data.GPUTypeIds.ElementsAs(ctx, &gpuTypeIds, false)
+	if diags.HasError() {
+		return types.Float64Null(), diags
+	}
+
+	price := catalog.maxPrice(gpuTypeIds, true, false)
+	if price == nil {
+		return types.Float64Null(), diags
+	}
+
+	cost := *price * float64(data.GPUCount.ValueInt64()) * float64(data.WorkersMin.ValueInt64())
+
+	return types.Float64Value(roundCost(cost)), diags
+}
+
+// roundCost rounds an hourly cost to a hundredth of a cent, so that floating
+// point noise does not show up in plans.
+func roundCost(cost float64) float64 {
+	return math.Round(cost*10000) / 10000
+}
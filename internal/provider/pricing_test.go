@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGPUCatalogRetryAfterError(t *testing.T) {
+	client, requests := countingServer(t, `{"data": {"gpuTypes": [{"id": "NVIDIA A40", "securePrice": 0.4}]}}`)
+	config := &providerConfig{client: client}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := config.gpuCatalog(cancelled); err == nil {
+		t.Fatal("gpuCatalog() with a cancelled context succeeded, want an error")
+	}
+
+	ctx := context.Background()
+	catalog, err := config.gpuCatalog(ctx)
+	if err != nil {
+		t.Fatalf("gpuCatalog() after an error = %s, want the catalog fetched again", err)
+	}
+	if _, ok := catalog["NVIDIA A40"]; !ok {
+		t.Errorf("gpuCatalog() = %v, want NVIDIA A40", catalog)
+	}
+	fetched := requests.Load()
+
+	if _, err := config.gpuCatalog(ctx); err != nil {
+		t.Fatalf("gpuCatalog() = %s", err)
+	}
+	if got := requests.Load(); got != fetched {
+		t.Errorf("gpuCatalog() made %d more requests, want the catalog cached", got-fetched)
+	}
+}
@@ -2,19 +2,27 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &runpodProvider{}
+	_ provider.Provider                  = &runpodProvider{}
+	_ provider.ProviderWithListResources = &runpodProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -47,7 +55,37 @@ func (p *runpodProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 			"api_key": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
-				Description: "The RunPod API key. Can also be set via the RUNPOD_API_KEY environment variable.",
+				Description: "The RunPod API key. Can also be set via the RUNPOD_API_KEY environment variable. Conflicts with `api_key_file`, `api_key_command` and `profile`.",
+			},
+			"api_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a file containing the RunPod API key. Surrounding whitespace is ignored.",
+			},
+			"api_key_command": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "A credential helper command, and its arguments, that prints the RunPod API key to standard output. The command is run directly, not by a shell.",
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the profile in `~/.runpod/config.toml`, the file written by `runpodctl config`, to read the API key from. `default` is the top-level `apikey`; other profiles are tables of the same name. When no API key is configured, the default profile is used as a last resort.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Default value of `deletion_protection` for resources that do not set it. Defaults to false.",
+			},
+			"default_env": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Environment variables added to every Pod and Template, and to the template of every Endpoint. Variables set on a resource take precedence. Default variables are not shown in resource `env` attributes.",
+			},
+			"max_total_cost_per_hr": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum combined cost, in USD per hour, of the Pods this provider creates during one apply. A Pod that would exceed it is deleted again and fails to create.",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Prefix added to the name of every Pod, Endpoint and Template. The prefix is not shown in resource `name` attributes.",
 			},
 		},
 	}
@@ -55,7 +93,139 @@ func (p *runpodProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 
 // runpodProviderModel maps provider schema data to a Go type.
 type runpodProviderModel struct {
-	ApiKey types.String `tfsdk:"api_key"`
+	ApiKey             types.String  `tfsdk:"api_key"`
+	ApiKeyFile         types.String  `tfsdk:"api_key_file"`
+	ApiKeyCommand      types.List    `tfsdk:"api_key_command"`
+	Profile            types.String  `tfsdk:"profile"`
+	DeletionProtection types.Bool    `tfsdk:"deletion_protection"`
+	DefaultEnv         types.Map     `tfsdk:"default_env"`
+	NamePrefix         types.String  `tfsdk:"name_prefix"`
+	MaxTotalCostPerHr  types.Float64 `tfsdk:"max_total_cost_per_hr"`
+}
+
+// providerConfig is passed to resources when the provider is configured.
+type providerConfig struct {
+	client *Client
+
+	// deletionProtection is the default for resources that do not set
+	// deletion_protection themselves.
+	deletionProtection bool
+
+	// defaultEnv is merged into the environment of every Pod and Template,
+	// and of the template of every Endpoint.
+	defaultEnv map[string]string
+
+	// namePrefix is prepended to the name of every Pod, Endpoint and
+	// Template.
+	namePrefix string
+
+	// maxTotalCostPerHr limits createdCostPerHr, the hourly cost of the Pods
+	// created by this provider instance, when set.
+	maxTotalCostPerHr *float64
+	createdCostPerHr  float64
+	costMu            sync.Mutex
+
+	// The GPU pricing catalog is fetched at most once per provider run. A
+	// failed fetch is not cached, so that a later call retries it.
+	gpuCatalogMu    sync.Mutex
+	gpuCatalogCache gpuCatalog
+
+	// publicKeysMu serializes changes to the SSH public keys of the account,
+	// which are stored as a single value.
+	publicKeysMu sync.Mutex
+
+	// The names of the account's secrets are fetched at most once per
+	// provider run; a failed fetch is not cached, so that a later call
+	// retries it. plannedSecrets holds the names of runpod_secret resources
+	// planned in this run, which may not exist yet.
+	secretNamesMu    sync.Mutex
+	secretNamesCache map[string]bool
+	plannedSecrets   map[string]bool
+	plannedSecretsMu sync.Mutex
+
+	// The Pods and Endpoints using each Network Volume are listed at most
+	// once per provider run. A failed listing is not cached, so that a later
+	// call retries it.
+	attachmentsMu          sync.Mutex
+	attachedPodsCache      map[string][]string
+	attachedEndpointsCache map[string][]string
+}
+
+// reserveCost adds the hourly cost of a newly created Pod to the total of
+// this run. It returns false, and leaves the total unchanged, when the total
+// would exceed max_total_cost_per_hr.
+func (c *providerConfig) reserveCost(cost float64) (float64, bool) {
+	c.costMu.Lock()
+	defer c.costMu.Unlock()
+
+	total := c.createdCostPerHr + cost
+	if c.maxTotalCostPerHr != nil && total > *c.maxTotalCostPerHr {
+		return total, false
+	}
+
+	c.createdCostPerHr = total
+	return total, true
+}
+
+// deletionProtected reports whether a resource with the given
+// deletion_protection value may not be deleted.
+func (c *providerConfig) deletionProtected(value types.Bool) bool {
+	if value.IsNull() || value.IsUnknown() {
+		return c.deletionProtection
+	}
+	return value.ValueBool()
+}
+
+// prefixedName returns the name sent to the API for a configured name.
+func (c *providerConfig) prefixedName(name string) string {
+	if name == "" {
+		return name
+	}
+	return c.namePrefix + name
+}
+
+// unprefixedName returns the configured name for a name reported by the API.
+func (c *providerConfig) unprefixedName(name string) string {
+	return strings.TrimPrefix(name, c.namePrefix)
+}
+
+// mergedEnv returns the environment sent to the API for a configured one.
+func (c *providerConfig) mergedEnv(env map[string]string) map[string]string {
+	if len(c.defaultEnv) == 0 {
+		return env
+	}
+
+	merged := make(map[string]string, len(c.defaultEnv)+len(env))
+	for k, v := range c.defaultEnv {
+		merged[k] = v
+	}
+	for k, v := range env {
+		merged[k] = v
+	}
+	return merged
+}
+
+// userEnv removes the default environment from an environment reported by
+// the API, so that only variables set on the resource end up in state. A
+// default variable is kept when the resource sets it too, or when its value
+// no longer matches the default, which shows up as drift until it is
+// reapplied.
+func (c *providerConfig) userEnv(prior types.Map, env map[string]string) map[string]string {
+	if len(c.defaultEnv) == 0 || env == nil {
+		return env
+	}
+
+	configured := prior.Elements()
+	user := make(map[string]string, len(env))
+	for k, v := range env {
+		if dv, ok := c.defaultEnv[k]; ok && dv == v {
+			if _, set := configured[k]; !set {
+				continue
+			}
+		}
+		user[k] = v
+	}
+	return user
 }
 
 // Configure prepares a RunPod API client for data sources and resources.
@@ -80,17 +250,80 @@ func (p *runpodProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if config.ApiKeyFile.IsUnknown() || config.ApiKeyCommand.IsUnknown() || config.Profile.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown RunPod API Key Source",
+			"The provider cannot create the RunPod API client as there is an unknown configuration value for api_key_file, api_key_command or profile. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DefaultEnv.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_env"),
+			"Unknown Default Environment",
+			"The provider cannot be configured as there is an unknown configuration value for default_env. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.NamePrefix.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_prefix"),
+			"Unknown Name Prefix",
+			"The provider cannot be configured as there is an unknown configuration value for name_prefix. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxTotalCostPerHr.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_total_cost_per_hr"),
+			"Unknown Maximum Total Cost",
+			"The provider cannot be configured as there is an unknown configuration value for max_total_cost_per_hr. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
+	var sources []string
+	for _, source := range []struct {
+		name string
+		set  bool
+	}{
+		{"api_key", !config.ApiKey.IsNull()},
+		{"api_key_file", !config.ApiKeyFile.IsNull()},
+		{"api_key_command", !config.ApiKeyCommand.IsNull()},
+		{"profile", !config.Profile.IsNull()},
+	} {
+		if source.set {
+			sources = append(sources, source.name)
+		}
+	}
+
+	if len(sources) > 1 {
+		resp.Diagnostics.AddError(
+			"Conflicting RunPod API Key Configuration",
+			fmt.Sprintf("Only one of api_key, api_key_file, api_key_command and profile may be set, got: %s.", strings.Join(sources, ", ")),
+		)
+		return
+	}
 
-	api_key := os.Getenv("RUNPOD_API_KEY")
+	// Use the configured source of the API key if there is one, then the
+	// RUNPOD_API_KEY environment variable, then the default runpodctl
+	// profile.
 
-	if !config.ApiKey.IsNull() {
-		api_key = config.ApiKey.ValueString()
+	api_key, keyPath, err := resolveAPIKey(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			keyPath,
+			"Unable to Read RunPod API Key",
+			fmt.Sprintf("The provider cannot read the RunPod API key, got error: %s", err),
+		)
+		return
 	}
 
 	// If any of the expected configurations are missing, return
@@ -98,10 +331,10 @@ func (p *runpodProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	if api_key == "" {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("api_key"),
+			keyPath,
 			"Missing RunPod API Key",
 			"The provider cannot create the RunPod API client as there is a missing or empty value for the RunPod API key. "+
-				"Set the API key value in the configuration or use the RUNPOD_API_KEY environment variable. "+
+				"Set the API key value in the configuration, use api_key_file, api_key_command or profile, or use the RUNPOD_API_KEY environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -110,18 +343,93 @@ func (p *runpodProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	// Create API client
+	var defaultEnv map[string]string
+	if !config.DefaultEnv.IsNull() {
+		resp.Diagnostics.Append(config.DefaultEnv.ElementsAs(ctx, &defaultEnv, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Create API client and make sure the key works before any resource
+	// relies on it.
 	client := NewClient(api_key)
+
+	tflog.Debug(ctx, "Validating RunPod API key")
+
+	if err := client.ValidateAPIKey(ctx); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			keyPath,
+			"Invalid RunPod API Key",
+			fmt.Sprintf("The RunPod API rejected the configured API key, got error: %s", err),
+		)
+		return
+	}
+
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &providerConfig{
+		client:             client,
+		deletionProtection: config.DeletionProtection.ValueBool(),
+		defaultEnv:         defaultEnv,
+		namePrefix:         config.NamePrefix.ValueString(),
+		maxTotalCostPerHr:  config.MaxTotalCostPerHr.ValueFloat64Pointer(),
+	}
+	resp.ListResourceData = resp.ResourceData
+}
+
+// resolveAPIKey returns the API key and the path of the attribute it was
+// configured with, used to attach diagnostics.
+func resolveAPIKey(ctx context.Context, config runpodProviderModel) (string, path.Path, error) {
+	switch {
+	case !config.ApiKey.IsNull():
+		return config.ApiKey.ValueString(), path.Root("api_key"), nil
+	case !config.ApiKeyFile.IsNull():
+		apiKey, err := readAPIKeyFile(config.ApiKeyFile.ValueString())
+		return apiKey, path.Root("api_key_file"), err
+	case !config.ApiKeyCommand.IsNull():
+		var args []string
+		if diags := config.ApiKeyCommand.ElementsAs(ctx, &args, false); diags.HasError() {
+			return "", path.Root("api_key_command"), errors.New("api_key_command must be a list of strings")
+		}
+		apiKey, err := runAPIKeyCommand(ctx, args)
+		return apiKey, path.Root("api_key_command"), err
+	}
+
+	if !config.Profile.IsNull() {
+		configPath, err := runpodctlConfigPath()
+		if err != nil {
+			return "", path.Root("profile"), err
+		}
+		apiKey, err := readProfileAPIKey(configPath, config.Profile.ValueString())
+		return apiKey, path.Root("profile"), err
+	}
+
+	if apiKey := os.Getenv("RUNPOD_API_KEY"); apiKey != "" {
+		return apiKey, path.Root("api_key"), nil
+	}
+
+	// The default profile is optional, so a missing home directory or file
+	// only means there is no key.
+	configPath, err := runpodctlConfigPath()
+	if err != nil {
+		return "", path.Root("api_key"), nil
+	}
+	if _, err := os.Stat(configPath); errors.Is(err, fs.ErrNotExist) {
+		return "", path.Root("api_key"), nil
+	}
+	apiKey, err := readProfileAPIKey(configPath, defaultProfile)
+	return apiKey, path.Root("profile"), err
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *runpodProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewAccountDataSource,
 		NewPodsDataSource,
+		NewExpiredPodsDataSource,
 		NewEndpointsDataSource,
 		NewNetworkVolumesDataSource,
+		NewNetworkVolumeDataSource,
 		NewTemplatesDataSource,
 	}
 }
@@ -132,5 +440,20 @@ func (p *runpodProvider) Resources(_ context.Context) []func() resource.Resource
 		NewPodResource,
 		NewEndpointResource,
 		NewNetworkVolumeResource,
+		NewPodGroupResource,
+		NewTemplateResource,
+		NewSSHKeyResource,
+		NewSecretResource,
+	}
+}
+
+// ListResources defines the list resources implemented in the provider, used
+// by terraform query.
+func (p *runpodProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewPodListResource,
+		NewEndpointListResource,
+		NewNetworkVolumeListResource,
+		NewTemplateListResource,
 	}
 }
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// IDIdentityModel is the identity of a resource identified by its RunPod ID,
+// which is unique across the API.
+type IDIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// idIdentitySchema sets the identity schema of a resource identified by its
+// RunPod ID.
+func idIdentitySchema(kind string, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The unique identifier of the " + kind + ".",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// setIDIdentity sets the identity of a resource to its ID. Identity is nil
+// when Terraform does not support resource identities.
+func setIDIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, IDIdentityModel{ID: types.StringValue(id)})
+}
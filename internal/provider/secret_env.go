@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// secretEnvHashKey is the private state key holding the hash of the
+	// secret_env values last sent to the API.
+	secretEnvHashKey = "secret_env_hash"
+
+	// secretEnvNamesKey is the private state key holding the names of the
+	// secret_env variables last sent to the API. secret_env is write-only,
+	// so they are not in state.
+	secretEnvNamesKey = "secret_env_names"
+)
+
+// secretEnvValues returns the configured secret_env.
+func secretEnvValues(ctx context.Context, secrets types.Map) (map[string]string, diag.Diagnostics) {
+	if secrets.IsNull() || secrets.IsUnknown() {
+		return nil, nil
+	}
+
+	var values map[string]string
+	diags := secrets.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// configSecretEnv returns secret_env from the configuration. It is
+// write-only, so it is never in the plan or state.
+func configSecretEnv(ctx context.Context, config tfsdk.Config) (map[string]string, diag.Diagnostics) {
+	var secrets types.Map
+	diags := config.GetAttribute(ctx, path.Root("secret_env"), &secrets)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	values, d := secretEnvValues(ctx, secrets)
+	diags.Append(d...)
+	return values, diags
+}
+
+// withSecretEnv adds the secret variables to an environment sent to the API.
+// They take precedence over env and the provider's default_env.
+func withSecretEnv(env map[string]string, secrets map[string]string) map[string]string {
+	if len(secrets) == 0 {
+		return env
+	}
+
+	merged := make(map[string]string, len(env)+len(secrets))
+	for k, v := range env {
+		merged[k] = v
+	}
+	for k, v := range secrets {
+		merged[k] = v
+	}
+	return merged
+}
+
+// withoutSecretEnv removes the named secret variables from an environment
+// reported by the API, so that they never end up in env.
+func withoutSecretEnv(env map[string]string, names []string) map[string]string {
+	if len(names) == 0 || env == nil {
+		return env
+	}
+
+	user := make(map[string]string, len(env))
+	for k, v := range env {
+		user[k] = v
+	}
+	for _, k := range names {
+		delete(user, k)
+	}
+	return user
+}
+
+// secretEnvNames returns the sorted names of the secret variables.
+func secretEnvNames(secrets map[string]string) []string {
+	names := make([]string, 0, len(secrets))
+	for k := range secrets {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// secretEnvHash hashes secret variables, so that changes can be detected
+// without storing their values.
+func secretEnvHash(secrets map[string]string) string {
+	h := sha256.New()
+	for _, k := range secretEnvNames(secrets) {
+		fmt.Fprintf(h, "%d:%s=%d:%s\n", len(k), k, len(secrets[k]), secrets[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// secretEnvHashJSON is the private state value recording secrets.
+func secretEnvHashJSON(secrets map[string]string) []byte {
+	return []byte(fmt.Sprintf("%q", secretEnvHash(secrets)))
+}
+
+// recordSecretEnv records the hash and names of the applied secret_env in
+// private state.
+func recordSecretEnv(ctx context.Context, private privateStateSetter, secrets map[string]string) diag.Diagnostics {
+	names, _ := json.Marshal(secretEnvNames(secrets))
+
+	diags := private.SetKey(ctx, secretEnvHashKey, secretEnvHashJSON(secrets))
+	diags.Append(private.SetKey(ctx, secretEnvNamesKey, names)...)
+	return diags
+}
+
+// recordedSecretEnvNames returns the names of the secret_env variables last
+// applied.
+func recordedSecretEnvNames(ctx context.Context, private privateState) ([]string, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, secretEnvNamesKey)
+	if len(raw) == 0 {
+		return nil, diags
+	}
+
+	var names []string
+	if err := json.Unmarshal(raw, &names); err != nil {
+		diags.AddError("Internal Error", fmt.Sprintf("Unable to decode recorded secret_env names, got error: %s", err))
+	}
+	return names, diags
+}
+
+// secretEnvChanged reports whether secrets differ from the secret_env last
+// applied. A resource without a recorded hash, e.g. after import, has none.
+func secretEnvChanged(ctx context.Context, private privateState, secrets map[string]string) (bool, diag.Diagnostics) {
+	recorded, diags := private.GetKey(ctx, secretEnvHashKey)
+	if len(recorded) == 0 {
+		return len(secrets) > 0, diags
+	}
+	return string(secretEnvHashJSON(secrets)) != string(recorded), diags
+}
+
+// planSecretEnvVersion plans secret_env_version. secret_env is write-only, so
+// a changed value does not show up in the plan by itself; instead the version
+// is incremented whenever the hash of the configured values differs from the
+// one recorded in private state, which makes Terraform apply the change.
+func planSecretEnvVersion(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	attr := path.Root("secret_env_version")
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attr, types.Int64Value(0))...)
+		return
+	}
+
+	var secrets types.Map
+	var version types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_env"), &secrets)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, attr, &version)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if secrets.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attr, types.Int64Unknown())...)
+		return
+	}
+
+	values, diags := secretEnvValues(ctx, secrets)
+	resp.Diagnostics.Append(diags...)
+	changed, diags := secretEnvChanged(ctx, req.Private, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attr, nextSecretEnvVersion(version, changed))...)
+}
+
+// nextSecretEnvVersion returns secret_env_version after applying secret_env.
+func nextSecretEnvVersion(prior types.Int64, changed bool) types.Int64 {
+	version := prior.ValueInt64()
+	if changed {
+		version++
+	}
+	return types.Int64Value(version)
+}
+
+// appliedSecretEnvVersion returns secret_env_version after an update: the
+// planned version, or when it could not be planned, the prior one
+// incremented if secret_env changed.
+func appliedSecretEnvVersion(planned, prior types.Int64, changed bool) types.Int64 {
+	if !planned.IsUnknown() {
+		return planned
+	}
+	return nextSecretEnvVersion(prior, changed)
+}
+
+// refreshSecretEnvVersion sets secret_env_version for state written before
+// it existed, or by import.
+func refreshSecretEnvVersion(version types.Int64) types.Int64 {
+	if version.IsNull() || version.IsUnknown() {
+		return types.Int64Value(0)
+	}
+	return version
+}
+
+// checkSecretEnv warns when the secret variables reported by the API no
+// longer match what Terraform last sent, i.e. they were changed outside of
+// Terraform. secret_env itself is write-only and never refreshed.
+func checkSecretEnv(ctx context.Context, private privateState, env map[string]string, resourceName string) diag.Diagnostics {
+	names, diags := recordedSecretEnvNames(ctx, private)
+	if len(names) == 0 {
+		return diags
+	}
+
+	recorded, d := private.GetKey(ctx, secretEnvHashKey)
+	diags.Append(d...)
+	if len(recorded) == 0 {
+		return diags
+	}
+
+	reported := make(map[string]string, len(names))
+	for _, k := range names {
+		if v, ok := env[k]; ok {
+			reported[k] = v
+		}
+	}
+
+	if string(secretEnvHashJSON(reported)) != string(recorded) {
+		diags.AddWarning(
+			"Secret Environment Changed Outside Terraform",
+			fmt.Sprintf("The secret_env variables of %s no longer match the values Terraform last applied. "+
+				"Change a value in secret_env, or taint the resource, to apply them again.", resourceName),
+		)
+	}
+	return diags
+}
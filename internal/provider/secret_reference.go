@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// secretReferencePattern matches references to RunPod secrets in environment
+// variable values, e.g. {{ RUNPOD_SECRET_hf_token }}.
+var secretReferencePattern = regexp.MustCompile(`\{\{\s*RUNPOD_SECRET_([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// secretReference returns the reference to the named secret.
+func secretReference(name string) string {
+	return fmt.Sprintf("{{ RUNPOD_SECRET_%s }}", name)
+}
+
+// planSecret records that a runpod_secret with the given name is part of the
+// plan, so that references to it are valid before it exists.
+func (c *providerConfig) planSecret(name string) {
+	c.plannedSecretsMu.Lock()
+	defer c.plannedSecretsMu.Unlock()
+
+	if c.plannedSecrets == nil {
+		c.plannedSecrets = map[string]bool{}
+	}
+	c.plannedSecrets[name] = true
+}
+
+// secretKnown reports whether the named secret exists in the account or is
+// planned in this run. The account's secrets are fetched on first use.
+func (c *providerConfig) secretKnown(ctx context.Context, name string) (bool, error) {
+	c.plannedSecretsMu.Lock()
+	planned := c.plannedSecrets[name]
+	c.plannedSecretsMu.Unlock()
+	if planned {
+		return true, nil
+	}
+
+	c.secretNamesMu.Lock()
+	defer c.secretNamesMu.Unlock()
+
+	if c.secretNamesCache == nil {
+		secrets, err := c.client.ListSecrets(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		names := make(map[string]bool, len(secrets))
+		for _, secret := range secrets {
+			names[secret.Name] = true
+		}
+		c.secretNamesCache = names
+	}
+
+	return c.secretNamesCache[name], nil
+}
+
+// validateSecretReferences checks that every secret referenced in env exists
+// or is managed by a runpod_secret in the same plan. Values that are not yet
+// known are skipped.
+//
+// A reference to a runpod_secret in the same plan must go through
+// runpod_secret.<name>.reference: written literally, it gives Terraform no
+// dependency on the runpod_secret, which may then be planned after this
+// check and fail it.
+func (c *providerConfig) validateSecretReferences(ctx context.Context, env types.Map, attr path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if env.IsNull() || env.IsUnknown() {
+		return diags
+	}
+
+	names := make([]string, 0, len(env.Elements()))
+	for k := range env.Elements() {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		value, ok := env.Elements()[k].(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		for _, match := range secretReferencePattern.FindAllStringSubmatch(value.ValueString(), -1) {
+			known, err := c.secretKnown(ctx, match[1])
+			if err != nil {
+				diags.AddAttributeWarning(
+					attr.AtMapKey(k),
+					"Unable to Check Secret Reference",
+					fmt.Sprintf("Unable to list secrets, got error: %s", err),
+				)
+				return diags
+			}
+			if !known {
+				diags.AddAttributeError(
+					attr.AtMapKey(k),
+					"Unknown Secret Reference",
+					fmt.Sprintf("%s references the secret %q, which does not exist. If a runpod_secret in this configuration "+
+						"creates it, use runpod_secret.<name>.reference instead of writing the reference literally, so that "+
+						"Terraform plans and creates the Secret first. Otherwise create it in the RunPod console.", k, match[1]),
+				)
+			}
+		}
+	}
+
+	return diags
+}
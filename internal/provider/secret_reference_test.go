@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSecretKnownRetryAfterError(t *testing.T) {
+	client, requests := countingServer(t, `{"data": {"myself": {"secrets": [{"id": "s1", "name": "hf_token"}]}}}`)
+	config := &providerConfig{client: client}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := config.secretKnown(cancelled, "hf_token"); err == nil {
+		t.Fatal("secretKnown() with a cancelled context succeeded, want an error")
+	}
+
+	ctx := context.Background()
+	known, err := config.secretKnown(ctx, "hf_token")
+	if err != nil {
+		t.Fatalf("secretKnown() after an error = %s, want the secrets listed again", err)
+	}
+	if !known {
+		t.Error("secretKnown(hf_token) = false, want true")
+	}
+	listed := requests.Load()
+
+	if known, err := config.secretKnown(ctx, "other"); err != nil || known {
+		t.Errorf("secretKnown(other) = %t, %v, want false", known, err)
+	}
+	if got := requests.Load(); got != listed {
+		t.Errorf("secretKnown() made %d more requests, want the secrets cached", got-listed)
+	}
+}
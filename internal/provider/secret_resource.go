@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &SecretResource{}
+var _ resource.ResourceWithImportState = &SecretResource{}
+var _ resource.ResourceWithModifyPlan = &SecretResource{}
+
+func NewSecretResource() resource.Resource {
+	return &SecretResource{}
+}
+
+// SecretResource defines the resource implementation.
+type SecretResource struct {
+	client *Client
+	config *providerConfig
+}
+
+// SecretResourceModel describes the resource data model.
+type SecretResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Value       types.String `tfsdk:"value"`
+	Description types.String `tfsdk:"description"`
+	Reference   types.String `tfsdk:"reference"`
+}
+
+func (r *SecretResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret"
+}
+
+func (r *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "RunPod Secret resource. A Secret is an account-level value, such as an API token, that Pods and Templates " +
+			"reference in environment variables as `{{ RUNPOD_SECRET_name }}` instead of containing it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the Secret.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the Secret, unique within the account.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the Secret. The API never returns it, so changes made outside of Terraform are not detected.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description of the Secret.",
+				Optional:            true,
+			},
+			"reference": schema.StringAttribute{
+				MarkdownDescription: "The reference to the Secret for use in `env`, e.g. `{{ RUNPOD_SECRET_hf_token }}`. Known at plan time. Use it rather than writing the reference literally, so that Terraform creates the Secret before the resources that use it.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *SecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*providerConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.client
+	r.config = config
+}
+
+// ModifyPlan computes reference from the name, so that Pods and Templates
+// using it are planned with the final value, and records the name so that
+// references to a Secret created in this run pass validation.
+func (r *SecretResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.config == nil {
+		return
+	}
+
+	var name types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() || name.IsUnknown() {
+		return
+	}
+
+	r.config.planSecret(name.ValueString())
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("reference"), secretReference(name.ValueString()))...)
+}
+
+func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SecretResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Secret", map[string]interface{}{"name": data.Name.ValueString()})
+
+	secret, err := r.client.CreateSecret(ctx, data.Name.ValueString(), data.Value.ValueString(), data.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create secret, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Created Secret", map[string]interface{}{"id": secret.ID})
+
+	updateStateFromSecret(&data, secret)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SecretResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading Secret", map[string]interface{}{"id": data.ID.ValueString()})
+
+	secrets, err := r.client.ListSecrets(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret, got error: %s", err))
+		return
+	}
+
+	for _, secret := range secrets {
+		if secret.ID == data.ID.ValueString() {
+			updateStateFromSecret(&data, &secret)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SecretResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating Secret", map[string]interface{}{"id": data.ID.ValueString()})
+
+	secret, err := r.client.UpdateSecret(ctx, data.ID.ValueString(), data.Value.ValueString(), data.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update secret, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Updated Secret", map[string]interface{}{"id": secret.ID})
+
+	updateStateFromSecret(&data, secret)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SecretResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting Secret", map[string]interface{}{"id": data.ID.ValueString()})
+
+	if err := r.client.DeleteSecret(ctx, data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete secret, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted Secret", map[string]interface{}{"id": data.ID.ValueString()})
+}
+
+// ImportState imports a Secret by ID, or by name given as name:<name>.
+func (r *SecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := importName(req.ID)
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	secrets, err := r.client.ListSecrets(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list secrets, got error: %s", err))
+		return
+	}
+
+	candidates := make([]importCandidate, 0, len(secrets))
+	for _, secret := range secrets {
+		candidates = append(candidates, importCandidate{id: secret.ID, name: secret.Name})
+	}
+
+	id, diags := resolveImportName("Secret", name, candidates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// updateStateFromSecret updates the Terraform state from a Secret API
+// response. The value is never returned and keeps its prior value.
+func updateStateFromSecret(data *SecretResourceModel, secret *Secret) {
+	data.ID = types.StringValue(secret.ID)
+	data.Name = types.StringValue(secret.Name)
+	data.Reference = types.StringValue(secretReference(secret.Name))
+	if secret.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(secret.Description)
+	}
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &SSHKeyResource{}
+var _ resource.ResourceWithImportState = &SSHKeyResource{}
+
+func NewSSHKeyResource() resource.Resource {
+	return &SSHKeyResource{}
+}
+
+// SSHKeyResource manages one SSH public key in the user settings of the
+// account.
+type SSHKeyResource struct {
+	client *Client
+	config *providerConfig
+}
+
+type SSHKeyResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	PublicKey types.String `tfsdk:"public_key"`
+}
+
+func (r *SSHKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_key"
+}
+
+func (r *SSHKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "RunPod SSH key resource. Adds an SSH public key to the user settings of the account, from where RunPod adds it to every Pod started afterwards. Keys added outside of Terraform are left alone; adding a key that is already in the account is an error, import it by its fingerprint instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The SHA256 fingerprint of the key, as printed by `ssh-keygen -l`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "The SSH public key in `authorized_keys` format, e.g. the contents of `~/.ssh/id_ed25519.pub`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SSHKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*providerConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.client
+	r.config = config
+}
+
+func (r *SSHKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SSHKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := strings.TrimSpace(data.PublicKey.ValueString())
+	fingerprint, err := publicKeyFingerprint(key)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("public_key"), "Invalid SSH Public Key", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "Adding SSH key", map[string]interface{}{"fingerprint": fingerprint})
+
+	r.config.publicKeysMu.Lock()
+	defer r.config.publicKeysMu.Unlock()
+
+	current, err := r.client.GetPublicKeys(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SSH keys, got error: %s", err))
+		return
+	}
+
+	// Adopting a key added outside of Terraform would remove it again on
+	// destroy, so it has to be imported instead.
+	keys := splitPublicKeys(current)
+	if _, found := findPublicKey(keys, fingerprint); found {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("public_key"),
+			"SSH Key Already Exists",
+			fmt.Sprintf("The SSH key %s is already in the settings of the account. "+
+				"Import it with `terraform import` and the ID %q to manage it with Terraform.", fingerprint, fingerprint),
+		)
+		return
+	}
+
+	keys = append(keys, key)
+	if err := r.client.UpdatePublicKeys(ctx, strings.Join(keys, "\n")); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add SSH key, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Added SSH key", map[string]interface{}{"fingerprint": fingerprint})
+
+	data.ID = types.StringValue(fingerprint)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SSHKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading SSH key", map[string]interface{}{"fingerprint": data.ID.ValueString()})
+
+	current, err := r.client.GetPublicKeys(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SSH keys, got error: %s", err))
+		return
+	}
+
+	key, found := findPublicKey(splitPublicKeys(current), data.ID.ValueString())
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Keep the configured spelling of the key, which may differ in
+	// whitespace.
+	if data.PublicKey.IsNull() {
+		data.PublicKey = types.StringValue(key)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement.
+	var data SSHKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SSHKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Removing SSH key", map[string]interface{}{"fingerprint": data.ID.ValueString()})
+
+	r.config.publicKeysMu.Lock()
+	defer r.config.publicKeysMu.Unlock()
+
+	current, err := r.client.GetPublicKeys(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SSH keys, got error: %s", err))
+		return
+	}
+
+	var keys []string
+	for _, key := range splitPublicKeys(current) {
+		if fingerprint, err := publicKeyFingerprint(key); err == nil && fingerprint == data.ID.ValueString() {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	if err := r.client.UpdatePublicKeys(ctx, strings.Join(keys, "\n")); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove SSH key, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Removed SSH key", map[string]interface{}{"fingerprint": data.ID.ValueString()})
+}
+
+func (r *SSHKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// findPublicKey returns the key with the given fingerprint. Keys that cannot
+// be parsed are skipped.
+func findPublicKey(keys []string, fingerprint string) (string, bool) {
+	for _, key := range keys {
+		if f, err := publicKeyFingerprint(key); err == nil && f == fingerprint {
+			return key, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// sshPublicKeyEnvVar is the environment variable RunPod images read
+	// authorized SSH keys from, one per line.
+	sshPublicKeyEnvVar = "PUBLIC_KEY"
+
+	// sshPort is the port exposed when expose_ssh is set.
+	sshPort = "22/tcp"
+)
+
+// sshKeysEnv renders ssh_public_keys into the environment sent to the API.
+func sshKeysEnv(ctx context.Context, env map[string]string, keys types.List) (map[string]string, diag.Diagnostics) {
+	if keys.IsNull() || keys.IsUnknown() {
+		return env, nil
+	}
+
+	var values []string
+	diags := keys.ElementsAs(ctx, &values, false)
+
+	withKeys := make(map[string]string, len(env)+1)
+	for k, v := range env {
+		withKeys[k] = v
+	}
+	withKeys[sshPublicKeyEnvVar] = strings.Join(values, "\n")
+	return withKeys, diags
+}
+
+// validateSSHKeysEnv reports an error when env sets PUBLIC_KEY as well as
+// ssh_public_keys, since sshKeysEnv would replace it without notice.
+func validateSSHKeysEnv(env types.Map, keys types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if keys.IsNull() || env.IsNull() || env.IsUnknown() {
+		return diags
+	}
+
+	if _, ok := env.Elements()[sshPublicKeyEnvVar]; ok {
+		diags.AddAttributeError(
+			path.Root("env").AtMapKey(sshPublicKeyEnvVar),
+			"Conflicting SSH Public Keys",
+			fmt.Sprintf("%s is set by ssh_public_keys. Move the keys in env.%s to ssh_public_keys, or remove ssh_public_keys.", sshPublicKeyEnvVar, sshPublicKeyEnvVar),
+		)
+	}
+	return diags
+}
+
+// refreshSSHKeys moves the keys rendered by sshKeysEnv out of an environment
+// reported by the API and into ssh_public_keys. PUBLIC_KEY stays in the
+// environment when ssh_public_keys is not set.
+func refreshSSHKeys(ctx context.Context, prior types.List, env map[string]string) (types.List, map[string]string, diag.Diagnostics) {
+	if prior.IsNull() {
+		return prior, env, nil
+	}
+
+	value, ok := env[sshPublicKeyEnvVar]
+	if !ok {
+		return types.ListNull(types.StringType), env, nil
+	}
+
+	user := make(map[string]string, len(env))
+	for k, v := range env {
+		if k != sshPublicKeyEnvVar {
+			user[k] = v
+		}
+	}
+
+	keys, diags := types.ListValueFrom(ctx, types.StringType, splitPublicKeys(value))
+	return keys, user, diags
+}
+
+// exposeSSHPort adds 22/tcp to the ports sent to the API when expose_ssh is
+// set.
+func exposeSSHPort(ports []string, expose types.Bool) []string {
+	if !expose.ValueBool() {
+		return ports
+	}
+	for _, port := range ports {
+		if port == sshPort {
+			return ports
+		}
+	}
+	return append(append([]string{}, ports...), sshPort)
+}
+
+// unexposeSSHPort removes the port added by exposeSSHPort from ports reported
+// by the API, unless it is configured in the prior ports.
+func unexposeSSHPort(ctx context.Context, prior types.List, ports []string, expose types.Bool) []string {
+	if !expose.ValueBool() {
+		return ports
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var configured []string
+		prior.ElementsAs(ctx, &configured, false)
+		for _, port := range configured {
+			if port == sshPort {
+				return ports
+			}
+		}
+	}
+
+	var user []string
+	for _, port := range ports {
+		if port != sshPort {
+			user = append(user, port)
+		}
+	}
+	return user
+}
+
+// splitPublicKeys splits a list of SSH public keys, one per line.
+func splitPublicKeys(value string) []string {
+	var keys []string
+	for _, line := range strings.Split(value, "\n") {
+		if key := strings.TrimSpace(line); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// publicKeyFingerprint returns the SHA256 fingerprint of an SSH public key in
+// authorized_keys format, as printed by ssh-keygen -l.
+func publicKeyFingerprint(key string) (string, error) {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return "", fmt.Errorf("expected a key type followed by the base64 encoded key")
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 encoded key: %w", err)
+	}
+
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]), nil
+}
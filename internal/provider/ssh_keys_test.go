@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateSSHKeysEnv(t *testing.T) {
+	ctx := context.Background()
+
+	env := func(values map[string]string) types.Map {
+		v, _ := types.MapValueFrom(ctx, types.StringType, values)
+		return v
+	}
+	keys, _ := types.ListValueFrom(ctx, types.StringType, []string{"ssh-ed25519 AAAA user@host"})
+
+	tests := []struct {
+		name      string
+		env       types.Map
+		keys      types.List
+		wantError bool
+	}{
+		{"both set", env(map[string]string{"PUBLIC_KEY": "ssh-rsa AAAA"}), keys, true},
+		{"both set, keys unknown", env(map[string]string{"PUBLIC_KEY": "ssh-rsa AAAA"}), types.ListUnknown(types.StringType), true},
+		{"only env", env(map[string]string{"PUBLIC_KEY": "ssh-rsa AAAA"}), types.ListNull(types.StringType), false},
+		{"only keys", env(map[string]string{"DEBUG": "1"}), keys, false},
+		{"env null", types.MapNull(types.StringType), keys, false},
+		{"env unknown", types.MapUnknown(types.StringType), keys, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateSSHKeysEnv(tt.env, tt.keys)
+			if got := diags.HasError(); got != tt.wantError {
+				t.Errorf("validateSSHKeysEnv() error = %t, want %t: %v", got, tt.wantError, diags)
+			}
+		})
+	}
+}
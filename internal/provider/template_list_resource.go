@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ list.ListResource = &TemplateListResource{}
+var _ list.ListResourceWithConfigure = &TemplateListResource{}
+
+func NewTemplateListResource() list.ListResource {
+	return &TemplateListResource{}
+}
+
+// TemplateListResource lists Templates for terraform query. Metadata and
+// Configure are those of the managed resource.
+type TemplateListResource struct {
+	TemplateResource
+}
+
+// TemplateListResourceModel describes the list resource data model.
+type TemplateListResourceModel struct {
+	Name                 types.String `tfsdk:"name"`
+	IncludeEndpointBound types.Bool   `tfsdk:"include_endpoint_bound"`
+}
+
+func (r *TemplateListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Templates of the account for `terraform query`. Public Templates of other users and Templates provided by RunPod are not listed.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "List only the Template with this name. The provider's `name_prefix` is prepended.",
+				Optional:            true,
+			},
+			"include_endpoint_bound": schema.BoolAttribute{
+				MarkdownDescription: "Whether to list Templates used by Serverless Endpoints. Defaults to true.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *TemplateListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	var config TemplateListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := &ListTemplatesFilter{
+		IncludeEndpointBound: config.IncludeEndpointBound.IsNull() || config.IncludeEndpointBound.ValueBool(),
+	}
+
+	tflog.Debug(ctx, "Listing Templates", map[string]interface{}{"include_endpoint_bound": filter.IncludeEndpointBound})
+
+	templates, err := r.client.ListTemplates(ctx, filter)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list templates, got error: %s", err))
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	resp.Results = func(push func(list.ListResult) bool) {
+		count := 0
+		for _, template := range templates {
+			if template.IsRunpod {
+				continue
+			}
+			if !config.Name.IsNull() && template.Name != r.config.prefixedName(config.Name.ValueString()) {
+				continue
+			}
+			if limitReached(req, count) {
+				return
+			}
+			count++
+
+			var data TemplateResourceModel
+			result := listResult(ctx, req, &r.TemplateResource, template.ID, template.Name, &data, func() diag.Diagnostics {
+				return r.updateStateFromTemplate(ctx, &data, &template, nil)
+			})
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,507 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &TemplateResource{}
+var _ resource.ResourceWithImportState = &TemplateResource{}
+var _ resource.ResourceWithIdentity = &TemplateResource{}
+var _ resource.ResourceWithModifyPlan = &TemplateResource{}
+var _ resource.ResourceWithValidateConfig = &TemplateResource{}
+
+func NewTemplateResource() resource.Resource {
+	return &TemplateResource{}
+}
+
+// TemplateResource defines the resource implementation.
+type TemplateResource struct {
+	client *Client
+	config *providerConfig
+}
+
+// TemplateResourceModel describes the resource data model.
+type TemplateResourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	Name                    types.String `tfsdk:"name"`
+	ImageName               types.String `tfsdk:"image_name"`
+	Category                types.String `tfsdk:"category"`
+	IsServerless            types.Bool   `tfsdk:"is_serverless"`
+	IsPublic                types.Bool   `tfsdk:"is_public"`
+	ContainerDiskInGb       types.Int64  `tfsdk:"container_disk_in_gb"`
+	VolumeInGb              types.Int64  `tfsdk:"volume_in_gb"`
+	VolumeMountPath         types.String `tfsdk:"volume_mount_path"`
+	Ports                   types.List   `tfsdk:"ports"`
+	Env                     types.Map    `tfsdk:"env"`
+	SecretEnv               types.Map    `tfsdk:"secret_env"`
+	SecretEnvVersion        types.Int64  `tfsdk:"secret_env_version"`
+	DockerEntrypoint        types.List   `tfsdk:"docker_entrypoint"`
+	DockerStartCmd          types.List   `tfsdk:"docker_start_cmd"`
+	ContainerRegistryAuthId types.String `tfsdk:"container_registry_auth_id"`
+	Readme                  types.String `tfsdk:"readme"`
+	SSHPublicKeys           types.List   `tfsdk:"ssh_public_keys"`
+	ExposeSSH               types.Bool   `tfsdk:"expose_ssh"`
+}
+
+func (r *TemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_template"
+}
+
+func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "RunPod Template resource. A Template defines the container image and settings used to create Pods or Serverless workers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the Template.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the Template. Names must be unique within the account.",
+				Required:            true,
+			},
+			"image_name": schema.StringAttribute{
+				MarkdownDescription: "The Docker image tag for the container run on Pods or workers created from the Template.",
+				Required:            true,
+			},
+			"category": schema.StringAttribute{
+				MarkdownDescription: "The compute category of the Template: NVIDIA, AMD or CPU. Defaults to NVIDIA.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("NVIDIA"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					oneOfValidator{values: []string{"NVIDIA", "AMD", "CPU"}},
+				},
+			},
+			"is_serverless": schema.BoolAttribute{
+				MarkdownDescription: "Set to true for a Template of Serverless workers, false for a Template of Pods. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"is_public": schema.BoolAttribute{
+				MarkdownDescription: "Set to true to make a Pod Template visible to other RunPod users. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"container_disk_in_gb": schema.Int64Attribute{
+				MarkdownDescription: "The amount of disk space, in gigabytes (GB), to allocate on the container disk. Data is wiped when the Pod or worker restarts.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"volume_in_gb": schema.Int64Attribute{
+				MarkdownDescription: "The amount of disk space, in gigabytes (GB), to allocate on the volume of Pods created from the Template.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"volume_mount_path": schema.StringAttribute{
+				MarkdownDescription: "The absolute path where the volume will be mounted in the filesystem.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ports": schema.ListAttribute{
+				MarkdownDescription: "A list of ports exposed on Pods created from the Template. Each port is formatted as [port number]/[protocol].",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"env": schema.MapAttribute{
+				MarkdownDescription: "Environment variables for Pods or workers created from the Template. Values may reference RunPod Secrets, e.g. `runpod_secret.example.reference`; referenced Secrets must exist or be managed in the same configuration.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_env": schema.MapAttribute{
+				MarkdownDescription: "Environment variables that hold secrets, such as API tokens. Write-only: values are never stored in plan or state, shown in `env` or refreshed from the API; only a hash is kept in private state to detect changes. Takes precedence over `env`. Requires Terraform 1.11 or later.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"secret_env_version": schema.Int64Attribute{
+				MarkdownDescription: "Incremented each time a change to `secret_env` is applied. `secret_env` is write-only, so this is what shows a change to it in plans.",
+				Computed:            true,
+			},
+			"docker_entrypoint": schema.ListAttribute{
+				MarkdownDescription: "If specified, overrides the ENTRYPOINT for the Docker image.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"docker_start_cmd": schema.ListAttribute{
+				MarkdownDescription: "If specified, overrides the start CMD for the Docker image.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"container_registry_auth_id": schema.StringAttribute{
+				MarkdownDescription: "Registry credentials ID for a private image.",
+				Optional:            true,
+			},
+			"readme": schema.StringAttribute{
+				MarkdownDescription: "README of the Template, in Markdown.",
+				Optional:            true,
+			},
+			"ssh_public_keys": schema.ListAttribute{
+				MarkdownDescription: "SSH public keys authorized on Pods created from the Template, in `authorized_keys` format. They are passed in the `" + sshPublicKeyEnvVar + "` environment variable read by RunPod images, and are not shown in `env`. Conflicts with `PUBLIC_KEY` in `env`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"expose_ssh": schema.BoolAttribute{
+				MarkdownDescription: "Set to true to expose port `" + sshPort + "` in addition to `ports`. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *TemplateResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema("Template", resp)
+}
+
+func (r *TemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var env types.Map
+	var keys types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("env"), &env)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ssh_public_keys"), &keys)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateSSHKeysEnv(env, keys)...)
+}
+
+func (r *TemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*providerConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.client
+	r.config = config
+}
+
+// ModifyPlan checks that the secrets referenced in env exist or are managed
+// in the same plan.
+func (r *TemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.config == nil {
+		return
+	}
+
+	planSecretEnvVersion(ctx, req, resp)
+
+	var env types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("env"), &env)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.config.validateSecretReferences(ctx, env, path.Root("env"))...)
+}
+
+func (r *TemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Template")
+
+	isServerless := data.IsServerless.ValueBool()
+	isPublic := data.IsPublic.ValueBool()
+	input := &TemplateCreateInput{
+		Name:                    r.config.prefixedName(data.Name.ValueString()),
+		ImageName:               data.ImageName.ValueString(),
+		Category:                data.Category.ValueString(),
+		IsServerless:            &isServerless,
+		IsPublic:                &isPublic,
+		VolumeMountPath:         data.VolumeMountPath.ValueString(),
+		ContainerRegistryAuthId: data.ContainerRegistryAuthId.ValueString(),
+		Readme:                  data.Readme.ValueString(),
+	}
+
+	if !data.ContainerDiskInGb.IsNull() && !data.ContainerDiskInGb.IsUnknown() {
+		diskSize := int(data.ContainerDiskInGb.ValueInt64())
+		input.ContainerDiskInGb = &diskSize
+	}
+	if !data.VolumeInGb.IsNull() && !data.VolumeInGb.IsUnknown() {
+		volumeSize := int(data.VolumeInGb.ValueInt64())
+		input.VolumeInGb = &volumeSize
+	}
+
+	secrets, diags := configSecretEnv(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	input.Ports, input.Env, input.DockerEntrypoint, input.DockerStartCmd, diags = r.containerInput(ctx, &data, secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := r.client.CreateTemplate(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create template, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Created Template", map[string]interface{}{"id": template.ID})
+
+	resp.Diagnostics.Append(recordSecretEnv(ctx, resp.Private, secrets)...)
+	data.SecretEnvVersion = types.Int64Value(0)
+
+	resp.Diagnostics.Append(r.updateStateFromTemplate(ctx, &data, template, secretEnvNames(secrets))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
+}
+
+func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading Template", map[string]interface{}{"id": data.ID.ValueString()})
+
+	template, err := r.client.GetTemplate(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read template, got error: %s", err))
+		return
+	}
+
+	secretNames, diags := recordedSecretEnvNames(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(checkSecretEnv(ctx, req.Private, template.Env, "template "+template.ID)...)
+	resp.Diagnostics.Append(r.updateStateFromTemplate(ctx, &data, template, secretNames)...)
+	data.SecretEnvVersion = refreshSecretEnvVersion(data.SecretEnvVersion)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
+}
+
+func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state TemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating Template", map[string]interface{}{"id": data.ID.ValueString()})
+
+	isPublic := data.IsPublic.ValueBool()
+	input := &TemplateUpdateInput{
+		Name:                    r.config.prefixedName(data.Name.ValueString()),
+		ImageName:               data.ImageName.ValueString(),
+		IsPublic:                &isPublic,
+		VolumeMountPath:         data.VolumeMountPath.ValueString(),
+		ContainerRegistryAuthId: data.ContainerRegistryAuthId.ValueString(),
+		Readme:                  data.Readme.ValueString(),
+	}
+
+	if !data.ContainerDiskInGb.IsNull() && !data.ContainerDiskInGb.IsUnknown() {
+		diskSize := int(data.ContainerDiskInGb.ValueInt64())
+		input.ContainerDiskInGb = &diskSize
+	}
+	if !data.VolumeInGb.IsNull() && !data.VolumeInGb.IsUnknown() {
+		volumeSize := int(data.VolumeInGb.ValueInt64())
+		input.VolumeInGb = &volumeSize
+	}
+
+	secrets, diags := configSecretEnv(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	input.Ports, input.Env, input.DockerEntrypoint, input.DockerStartCmd, diags = r.containerInput(ctx, &data, secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := r.client.UpdateTemplate(ctx, data.ID.ValueString(), input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update template, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Updated Template", map[string]interface{}{"id": template.ID})
+
+	secretsChanged, diags := secretEnvChanged(ctx, req.Private, secrets)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(recordSecretEnv(ctx, resp.Private, secrets)...)
+	data.SecretEnvVersion = appliedSecretEnvVersion(data.SecretEnvVersion, state.SecretEnvVersion, secretsChanged)
+
+	resp.Diagnostics.Append(r.updateStateFromTemplate(ctx, &data, template, secretEnvNames(secrets))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID.ValueString())...)
+}
+
+func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting Template", map[string]interface{}{"id": data.ID.ValueString()})
+
+	if err := r.client.DeleteTemplate(ctx, data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete template, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted Template", map[string]interface{}{"id": data.ID.ValueString()})
+}
+
+// ImportState imports a Template by ID or identity, or by name given as
+// name:<name>.
+func (r *TemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := importName(req.ID)
+	if !ok {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		return
+	}
+
+	templates, err := r.client.ListTemplates(ctx, &ListTemplatesFilter{IncludeEndpointBound: true})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list templates, got error: %s", err))
+		return
+	}
+
+	candidates := make([]importCandidate, 0, len(templates))
+	for _, template := range templates {
+		candidates = append(candidates, importCandidate{id: template.ID, name: template.Name})
+	}
+
+	id, diags := resolveImportName("Template", r.config.prefixedName(name), candidates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// containerInput builds the ports, environment, entrypoint and start command
+// sent to the API, which are the same for creates and updates. secrets is
+// the configured secret_env.
+func (r *TemplateResource) containerInput(ctx context.Context, data *TemplateResourceModel, secrets map[string]string) ([]string, map[string]string, []string, []string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var ports, entrypoint, startCmd []string
+	var env map[string]string
+
+	if !data.Ports.IsNull() && !data.Ports.IsUnknown() {
+		diags.Append(data.Ports.ElementsAs(ctx, &ports, false)...)
+	}
+	if !data.Env.IsNull() && !data.Env.IsUnknown() {
+		diags.Append(data.Env.ElementsAs(ctx, &env, false)...)
+	}
+	if !data.DockerEntrypoint.IsNull() && !data.DockerEntrypoint.IsUnknown() {
+		diags.Append(data.DockerEntrypoint.ElementsAs(ctx, &entrypoint, false)...)
+	}
+	if !data.DockerStartCmd.IsNull() && !data.DockerStartCmd.IsUnknown() {
+		diags.Append(data.DockerStartCmd.ElementsAs(ctx, &startCmd, false)...)
+	}
+
+	env, d := sshKeysEnv(ctx, r.config.mergedEnv(env), data.SSHPublicKeys)
+	diags.Append(d...)
+	env = withSecretEnv(env, secrets)
+
+	return exposeSSHPort(ports, data.ExposeSSH), env, entrypoint, startCmd, diags
+}
+
+// updateStateFromTemplate updates the Terraform state from a Template API
+// response. The secret_env variables named by secretNames are left out of
+// env.
+func (r *TemplateResource) updateStateFromTemplate(ctx context.Context, data *TemplateResourceModel, template *Template, secretNames []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	data.ID = types.StringValue(template.ID)
+	data.Name = refreshString(data.Name, r.config.unprefixedName(template.Name))
+	data.ImageName = refreshString(data.ImageName, template.ImageName)
+	data.Category = refreshString(data.Category, template.Category)
+	data.IsServerless = types.BoolValue(template.IsServerless)
+	data.IsPublic = types.BoolValue(template.IsPublic)
+	data.VolumeMountPath = refreshString(data.VolumeMountPath, template.VolumeMountPath)
+	data.ContainerDiskInGb = types.Int64Value(int64(template.ContainerDiskInGb))
+	data.VolumeInGb = types.Int64Value(int64(template.VolumeInGb))
+	data.ContainerRegistryAuthId = refreshString(data.ContainerRegistryAuthId, template.ContainerRegistryAuthId)
+	data.Readme = refreshString(data.Readme, template.Readme)
+
+	env := withoutSecretEnv(r.config.userEnv(data.Env, template.Env), secretNames)
+	data.SSHPublicKeys, env, d = refreshSSHKeys(ctx, data.SSHPublicKeys, env)
+	diags.Append(d...)
+	data.Env, d = refreshStringMap(ctx, data.Env, env)
+	diags.Append(d...)
+	data.Ports, d = refreshStringList(ctx, data.Ports, unexposeSSHPort(ctx, data.Ports, template.Ports, data.ExposeSSH))
+	diags.Append(d...)
+	data.DockerEntrypoint, d = refreshStringList(ctx, data.DockerEntrypoint, template.DockerEntrypoint)
+	diags.Append(d...)
+	data.DockerStartCmd, d = refreshStringList(ctx, data.DockerStartCmd, template.DockerStartCmd)
+	diags.Append(d...)
+
+	if data.Category.IsNull() {
+		data.Category = types.StringValue("NVIDIA")
+	}
+	if data.ExposeSSH.IsNull() {
+		data.ExposeSSH = types.BoolValue(false)
+	}
+
+	return diags
+}
@@ -105,7 +105,7 @@ func (d *TemplatesDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	tflog.Debug(ctx, "Reading Templates data source")
 
-	templates, err := d.client.ListTemplates(ctx)
+	templates, err := d.client.ListTemplates(ctx, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list templates, got error: %s", err))
 		return
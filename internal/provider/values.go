@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The refresh helpers below map a value reported by the API onto the prior
+// Terraform value of an attribute. A reported value always wins so that
+// changes made outside of Terraform show up in plans. When the API omits a
+// value the prior one is kept, except that unknown values planned for
+// computed attributes resolve to null.
+
+func refreshString(prior types.String, value string) types.String {
+	if value != "" {
+		return types.StringValue(value)
+	}
+	if prior.IsUnknown() {
+		return types.StringNull()
+	}
+	return prior
+}
+
+func refreshInt64(prior types.Int64, value *int) types.Int64 {
+	if value != nil {
+		return types.Int64Value(int64(*value))
+	}
+	if prior.IsUnknown() {
+		return types.Int64Null()
+	}
+	return prior
+}
+
+func refreshBool(prior types.Bool, value *bool) types.Bool {
+	if value != nil {
+		return types.BoolValue(*value)
+	}
+	if prior.IsUnknown() {
+		return types.BoolNull()
+	}
+	return prior
+}
+
+// refreshStringList treats an empty API list as cleared. A prior empty list
+// is kept as is so that an explicit `[]` in configuration does not diff.
+func refreshStringList(ctx context.Context, prior types.List, values []string) (types.List, diag.Diagnostics) {
+	if len(values) > 0 {
+		return types.ListValueFrom(ctx, types.StringType, values)
+	}
+	if prior.IsUnknown() || len(prior.Elements()) > 0 {
+		return types.ListNull(types.StringType), nil
+	}
+	return prior, nil
+}
+
+// refreshUnorderedList is refreshStringList for lists the API may return in
+// a different order. The prior list, and its order, is kept when it holds the
+// same elements.
+func refreshUnorderedList(ctx context.Context, prior types.List, values []string) (types.List, diag.Diagnostics) {
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var elements []string
+		diags := prior.ElementsAs(ctx, &elements, false)
+		if diags.HasError() {
+			return prior, diags
+		}
+		if sameElements(elements, values) {
+			return prior, nil
+		}
+	}
+	return refreshStringList(ctx, prior, values)
+}
+
+// sameElements reports whether a and b hold the same elements, including
+// duplicates, in any order.
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+		if counts[s] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// refreshStringMap treats an empty API map as cleared, like refreshStringList.
+func refreshStringMap(ctx context.Context, prior types.Map, values map[string]string) (types.Map, diag.Diagnostics) {
+	if len(values) > 0 {
+		return types.MapValueFrom(ctx, types.StringType, values)
+	}
+	if prior.IsUnknown() || len(prior.Elements()) > 0 {
+		return types.MapNull(types.StringType), nil
+	}
+	return prior, nil
+}
+
+// refreshPlacementList refreshes a list of candidates, e.g. GPU types, from
+// the one that was picked, which is all the API reports. The list is kept, in
+// its prior order, while it includes the placement, and is otherwise replaced
+// by it, so that a placement outside of the list shows up in plans.
+func refreshPlacementList(ctx context.Context, prior types.List, placement string) (types.List, diag.Diagnostics) {
+	if prior.IsUnknown() {
+		prior = types.ListNull(types.StringType)
+	}
+	if placement == "" || prior.IsNull() {
+		return prior, nil
+	}
+
+	for _, element := range prior.Elements() {
+		if s, ok := element.(types.String); ok && s.ValueString() == placement {
+			return prior, nil
+		}
+	}
+	return types.ListValueFrom(ctx, types.StringType, []string{placement})
+}
+
+// stringSetValue converts an API string slice to a set, treating an empty
+// slice as unset.
+func stringSetValue(ctx context.Context, values []string) (types.Set, diag.Diagnostics) {
+	if len(values) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, values)
+}
+
+// int64PointerValue converts an optional API integer to an Int64, null when
+// the API omitted it.
+func int64PointerValue(value *int) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
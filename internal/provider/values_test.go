@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRefreshString(t *testing.T) {
+	tests := []struct {
+		name  string
+		prior types.String
+		value string
+		want  types.String
+	}{
+		{"reported", types.StringValue("old"), "new", types.StringValue("new")},
+		{"reported over null", types.StringNull(), "new", types.StringValue("new")},
+		{"omitted keeps prior", types.StringValue("old"), "", types.StringValue("old")},
+		{"omitted keeps null", types.StringNull(), "", types.StringNull()},
+		{"omitted resolves unknown", types.StringUnknown(), "", types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refreshString(tt.prior, tt.value); !got.Equal(tt.want) {
+				t.Errorf("refreshString(%s, %q) = %s, want %s", tt.prior, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshInt64(t *testing.T) {
+	zero, five := 0, 5
+
+	tests := []struct {
+		name  string
+		prior types.Int64
+		value *int
+		want  types.Int64
+	}{
+		{"reported", types.Int64Value(3), &five, types.Int64Value(5)},
+		{"reported zero", types.Int64Value(3), &zero, types.Int64Value(0)},
+		{"omitted keeps prior", types.Int64Value(3), nil, types.Int64Value(3)},
+		{"omitted keeps null", types.Int64Null(), nil, types.Int64Null()},
+		{"omitted resolves unknown", types.Int64Unknown(), nil, types.Int64Null()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refreshInt64(tt.prior, tt.value); !got.Equal(tt.want) {
+				t.Errorf("refreshInt64(%s) = %s, want %s", tt.prior, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshBool(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name  string
+		prior types.Bool
+		value *bool
+		want  types.Bool
+	}{
+		{"reported", types.BoolValue(false), &yes, types.BoolValue(true)},
+		{"reported false", types.BoolValue(true), &no, types.BoolValue(false)},
+		{"omitted keeps prior", types.BoolValue(true), nil, types.BoolValue(true)},
+		{"omitted resolves unknown", types.BoolUnknown(), nil, types.BoolNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refreshBool(tt.prior, tt.value); !got.Equal(tt.want) {
+				t.Errorf("refreshBool(%s) = %s, want %s", tt.prior, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshStringList(t *testing.T) {
+	ctx := context.Background()
+
+	list := func(elements ...string) types.List {
+		v, _ := types.ListValueFrom(ctx, types.StringType, append([]string{}, elements...))
+		return v
+	}
+
+	tests := []struct {
+		name   string
+		prior  types.List
+		values []string
+		want   types.List
+	}{
+		{"reported", list("a"), []string{"b", "c"}, list("b", "c")},
+		{"reported over null", types.ListNull(types.StringType), []string{"a"}, list("a")},
+		{"cleared", list("a"), nil, types.ListNull(types.StringType)},
+		{"empty keeps empty", list(), nil, list()},
+		{"empty keeps null", types.ListNull(types.StringType), []string{}, types.ListNull(types.StringType)},
+		{"empty resolves unknown", types.ListUnknown(types.StringType), nil, types.ListNull(types.StringType)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := refreshStringList(ctx, tt.prior, tt.values)
+			if diags.HasError() {
+				t.Fatalf("refreshStringList() diagnostics = %v", diags)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("refreshStringList(%s, %v) = %s, want %s", tt.prior, tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshStringMap(t *testing.T) {
+	ctx := context.Background()
+
+	env := func(values map[string]string) types.Map {
+		v, _ := types.MapValueFrom(ctx, types.StringType, values)
+		return v
+	}
+
+	tests := []struct {
+		name   string
+		prior  types.Map
+		values map[string]string
+		want   types.Map
+	}{
+		{"reported", env(map[string]string{"A": "1"}), map[string]string{"A": "2"}, env(map[string]string{"A": "2"})},
+		{"cleared", env(map[string]string{"A": "1"}), nil, types.MapNull(types.StringType)},
+		{"empty keeps empty", env(map[string]string{}), nil, env(map[string]string{})},
+		{"empty keeps null", types.MapNull(types.StringType), map[string]string{}, types.MapNull(types.StringType)},
+		{"empty resolves unknown", types.MapUnknown(types.StringType), nil, types.MapNull(types.StringType)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := refreshStringMap(ctx, tt.prior, tt.values)
+			if diags.HasError() {
+				t.Fatalf("refreshStringMap() diagnostics = %v", diags)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("refreshStringMap(%s, %v) = %s, want %s", tt.prior, tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshUnorderedList(t *testing.T) {
+	ctx := context.Background()
+
+	list := func(elements ...string) types.List {
+		v, _ := types.ListValueFrom(ctx, types.StringType, append([]string{}, elements...))
+		return v
+	}
+
+	tests := []struct {
+		name   string
+		prior  types.List
+		values []string
+		want   types.List
+	}{
+		{"same order", list("a", "b"), []string{"a", "b"}, list("a", "b")},
+		{"reordered keeps prior", list("a", "b", "c"), []string{"c", "a", "b"}, list("a", "b", "c")},
+		{"different element", list("a", "b"), []string{"a", "c"}, list("a", "c")},
+		{"extra element", list("a"), []string{"b", "a"}, list("b", "a")},
+		{"duplicates differ", list("a", "a", "b"), []string{"a", "b", "b"}, list("a", "b", "b")},
+		{"reported over null", types.ListNull(types.StringType), []string{"b", "a"}, list("b", "a")},
+		{"cleared", list("a"), nil, types.ListNull(types.StringType)},
+		{"empty resolves unknown", types.ListUnknown(types.StringType), nil, types.ListNull(types.StringType)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := refreshUnorderedList(ctx, tt.prior, tt.values)
+			if diags.HasError() {
+				t.Fatalf("refreshUnorderedList() diagnostics = %v", diags)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("refreshUnorderedList(%s, %v) = %s, want %s", tt.prior, tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshPlacementList(t *testing.T) {
+	ctx := context.Background()
+
+	list := func(elements ...string) types.List {
+		v, _ := types.ListValueFrom(ctx, types.StringType, append([]string{}, elements...))
+		return v
+	}
+
+	tests := []struct {
+		name      string
+		prior     types.List
+		placement string
+		want      types.List
+	}{
+		{"placed on first", list("NVIDIA A40", "NVIDIA L4"), "NVIDIA A40", list("NVIDIA A40", "NVIDIA L4")},
+		{"placed on later keeps order", list("NVIDIA A40", "NVIDIA L4"), "NVIDIA L4", list("NVIDIA A40", "NVIDIA L4")},
+		{"placed outside", list("NVIDIA A40", "NVIDIA L4"), "NVIDIA A100", list("NVIDIA A100")},
+		{"unset stays unset", types.ListNull(types.StringType), "NVIDIA A40", types.ListNull(types.StringType)},
+		{"omitted keeps prior", list("NVIDIA A40"), "", list("NVIDIA A40")},
+		{"unknown resolves to null", types.ListUnknown(types.StringType), "NVIDIA A40", types.ListNull(types.StringType)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := refreshPlacementList(ctx, tt.prior, tt.placement)
+			if diags.HasError() {
+				t.Fatalf("refreshPlacementList() diagnostics = %v", diags)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("refreshPlacementList(%s, %q) = %s, want %s", tt.prior, tt.placement, got, tt.want)
+			}
+		})
+	}
+}
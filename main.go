@@ -7,6 +7,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
@@ -23,6 +24,11 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		export(os.Args[2:])
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -39,3 +45,21 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// export writes the resources of a RunPod account as Terraform configuration,
+// e.g. terraform-provider-runpod export -out ./generated.
+func export(args []string) {
+	var out, profile string
+
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.StringVar(&out, "out", "generated", "directory to write the generated configuration to")
+	flags.StringVar(&profile, "profile", "", "runpodctl profile to read the API key from instead of RUNPOD_API_KEY")
+	flags.Parse(args)
+
+	count, err := provider.Export(context.Background(), provider.ExportOptions{OutDir: out, Profile: profile})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	log.Printf("Exported %d resources to %s", count, out)
+}